@@ -28,26 +28,50 @@ func getCMDs() *cobra.Command {
 
 func getCMDsFlags(getCmd *cobra.Command) {
 	getCmd.PersistentFlags().String("user-agent", "", "User agent to use when requesting URLs.")
+	getCmd.PersistentFlags().String("crawl-operator-email", "", "Email address of the crawl operator, included in the default User-Agent so that webmasters can reach you. Ignored if --user-agent is set.")
+	getCmd.PersistentFlags().String("crawl-info-url", "", "URL of a page describing the crawl, included in the default User-Agent. Ignored if --user-agent is set.")
+	getCmd.PersistentFlags().StringArray("url-metadata", []string{}, "Annotation to attach to every archived URL, as key=value, e.g. collection=news. The annotations are stored in url_metadata.db in the job directory. Can be repeated.")
+	getCmd.PersistentFlags().StringArray("host-user-agent", []string{}, "User-Agent to use for a specific host, taking precedence over --user-agent, as host=user-agent. Can be repeated.")
 	getCmd.PersistentFlags().String("job", "", "Job name to use, will determine the path for the persistent queue, seencheck database, and WARC files.")
 	getCmd.PersistentFlags().IntP("workers", "w", 1, "Number of concurrent workers to run.")
 	getCmd.PersistentFlags().Int("max-concurrent-assets", 1, "Max number of concurrent assets to fetch PER worker. E.g. if you have 100 workers and this setting at 8, Zeno could do up to 800 concurrent requests at any time.")
+	getCmd.PersistentFlags().Int("high-latency-threshold-ms", 0, "When the moving average of the fetch latency goes above this number of milliseconds, reduce --max-concurrent-assets by 1 (down to 1). 0 disables the latency-based adjustment.")
+	getCmd.PersistentFlags().Int("low-latency-threshold-ms", 500, "When the moving average of the fetch latency goes below this number of milliseconds, restore --max-concurrent-assets by 1 (up to its configured value). Only used with --high-latency-threshold-ms.")
 	getCmd.PersistentFlags().Int("max-hops", 0, "Maximum number of hops to execute.")
+	getCmd.PersistentFlags().Int("max-path-depth", 0, "Maximum number of segments in the path of a URL, deeper URLs are skipped. Unlike --max-hops, this applies to the URL structure rather than to the link graph. 0 means no limit.")
+	getCmd.PersistentFlags().Bool("follow-pagination", false, "Follow rel=\"next\" links (from the Link header or HTML) with the same hop count as the current page, so that paginated resources are fully crawled regardless of --max-hops.")
+	getCmd.PersistentFlags().Bool("stay-on-registered-domain", false, "Only enqueue the outlinks whose registered domain (eTLD+1, e.g. example.co.uk) is the one of a seed, so that subdomains are crawled but not external sites. The seed domains are kept in the job directory across restarts.")
+	getCmd.PersistentFlags().Int("max-pagination-depth", 100, "Maximum number of pages followed in a pagination chain with --follow-pagination. 0 means no limit.")
 	getCmd.PersistentFlags().String("cookies", "", "File containing cookies that will be used for requests.")
 	getCmd.PersistentFlags().Bool("disable-seencheck", false, "Disable the (remote or local) seencheck that avoid re-crawling of URIs.")
 	getCmd.PersistentFlags().Bool("api", false, "Enable API")
 	getCmd.PersistentFlags().Int("api-port", 9090, "Port to listen on for the API.")
+	getCmd.PersistentFlags().String("control-socket", "", "Path of a UNIX domain socket accepting JSON-RPC 2.0 requests to manage Zeno locally. Methods are stats, pause (with an optional reason param), resume and add-host-headers (with host and headers params).")
 	getCmd.PersistentFlags().Int("max-redirect", 20, "Specifies the maximum number of redirections to follow for a resource.")
 	getCmd.PersistentFlags().Int("max-retry", 5, "Number of retry if error happen when executing HTTP request.")
 	getCmd.PersistentFlags().Int("http-timeout", -1, "Number of seconds to wait before timing out a request. Note: this will CANCEL large files download.")
 	getCmd.PersistentFlags().Int("http-read-deadline", 60, "Number of seconds to wait before timing out a (blocking) read.")
+	getCmd.PersistentFlags().Uint64("html-size-limit", 0, "Maximum number of bytes read (and archived) from HTML pages whose Content-Length is above this value, the rest of the body is dropped. 0 means no limit.")
+	getCmd.PersistentFlags().Int("max-conns-per-host", 0, "Maximum number of simultaneous connections to a single host, across all workers. 0 means no limit.")
+	getCmd.PersistentFlags().Int("http-body-timeout", 0, "Number of seconds to wait for the whole body to be read, starting once the response headers are received. Unlike --http-read-deadline, which is reset after each read, this cuts off slow servers trickling the body, but also large downloads taking longer. 0 means no limit.")
+	getCmd.PersistentFlags().Int("http-dial-timeout", 10, "Number of seconds to wait for a connection to be established. Unlike --http-timeout, this doesn't limit the time spent downloading the body.")
 	getCmd.PersistentFlags().StringSlice("domains-crawl", []string{}, "Naive domains, full URLs or regexp to match against any URL to determine hop behaviour for outlinks. If an outlink URL is matched it will be queued to crawl with a hop of 0. This flag helps crawling entire domains while doing non-focused crawls.")
 	getCmd.PersistentFlags().StringSlice("disable-html-tag", []string{}, "Specify HTML tag to not extract assets from")
+	getCmd.PersistentFlags().Int("outlinks-cache-size", 0, "Number of bodies for which extracted outlinks are cached by content hash, so that the same content served by multiple URLs is only parsed once. 0 disables the cache.")
+	getCmd.PersistentFlags().Int("json-max-depth", 10, "Maximum nesting depth at which URLs are extracted from JSON documents.")
+	getCmd.PersistentFlags().Bool("honor-meta-robots", false, "Honor <meta name=\"robots\"> tags: links of nofollow pages are not extracted and assets of noarchive pages are not captured.")
 	getCmd.PersistentFlags().Bool("capture-alternate-pages", false, "If turned on, <link> HTML tags with \"alternate\" values for their \"rel\" attribute will be archived.")
 	getCmd.PersistentFlags().StringSlice("exclude-host", []string{}, "Exclude a specific host from the crawl, note that it will not exclude the domain if it is encountered as an asset for another web page.")
 	getCmd.PersistentFlags().StringSlice("include-host", []string{}, "Only crawl specific hosts, note that it will not include the domain if it is encountered as an asset for another web page.")
 	getCmd.PersistentFlags().StringSlice("include-string", []string{}, "Only crawl URLs containing this string.")
 	getCmd.PersistentFlags().Int("crawl-time-limit", 0, "Number of seconds until the crawl will automatically set itself into the finished state.")
 	getCmd.PersistentFlags().Int("crawl-max-time-limit", 0, "Number of seconds until the crawl will automatically panic itself. Default to crawl-time-limit + (crawl-time-limit / 10)")
+	getCmd.PersistentFlags().StringSlice("crawl-schedule-window", []string{}, "Only crawl a host during these windows of local time, its URLs are skipped (marked as failed) outside of them. Format is host=DAY:START-END with DAY a three-letter weekday or * for every day, and hours from 0 to 24, e.g. example.com=sat:0-24 or example.com=*:22-6 (spans midnight). Can be repeated, hosts without windows are always crawled.")
+	getCmd.PersistentFlags().String("recrawl-cron", "", "Crawl the seeds given to get url again on this schedule, as a standard 5-fields cron expression, e.g. \"0 2 * * *\" for nightly recrawls. Requires --disable-seencheck.")
+	getCmd.PersistentFlags().String("recrawl-overlap", "skip", "What to do when a recrawl is due while the previous one is still being crawled: skip it, or queue it to start once the previous one is done.")
+	getCmd.PersistentFlags().Duration("startup-jitter", 0, "Wait for a random duration between 0 and this value before starting the crawl, to stagger instances launched simultaneously.")
+	getCmd.PersistentFlags().Uint64("max-total-urls", 0, "Maximum number of URLs to enqueue during this crawl session. Once reached, newly discovered URLs are discarded and Zeno stops when the queue is drained. 0 means no limit.")
+	getCmd.PersistentFlags().Uint64("max-total-bytes", 0, "Stop the crawl gracefully once this number of response body bytes has been archived. 0 means no limit.")
 	getCmd.PersistentFlags().StringSlice("exclude-string", []string{}, "Discard any (discovered) URLs containing this string.")
 	getCmd.PersistentFlags().StringSlice("exclusion-file", []string{}, "File containing regex to apply on URLs for exclusion. If the path start with http or https, it will be treated as a URL of a file to download.")
 	getCmd.PersistentFlags().Float64("min-space-required", 0, "Minimum space required in GB to continue the crawl. Default will be 50GB * (total disk space / 256GB) if total disk space is less than 256GB, else 50GB.")
@@ -58,12 +82,29 @@ func getCMDsFlags(getCmd *cobra.Command) {
 	getCmd.PersistentFlags().Bool("disable-ipv4", false, "Disable IPv4 for requests.")
 	getCmd.PersistentFlags().Bool("disable-ipv6", false, "Disable IPv6 for requests.")
 	getCmd.PersistentFlags().Bool("ipv6-anyip", false, "Use AnyIP kernel feature for requests. (only IPv6, need --random-local-ip)")
+	getCmd.PersistentFlags().String("network-interface", "", "Network interface to watch, the crawl is paused while it has no IP address (e.g. on a WiFi dropout).")
+	getCmd.PersistentFlags().Bool("ping-check-new-hosts", false, "Check in the background that a host accepts TCP connections on port 80 or 443 the first time it is crawled. Once the check failed, the URLs of the host are skipped. Ignored when using --proxy.")
+	getCmd.PersistentFlags().Duration("dead-host-ttl", time.Duration(1*time.Hour), "How long a host that failed the --ping-check-new-hosts check is considered dead.")
+	getCmd.PersistentFlags().Int("dup-window-size", 0, "Number of recently dispatched URLs remembered to avoid fetching the same URL twice in a short time, e.g. when found by two concurrent extractions. 0 disables it.")
+	getCmd.PersistentFlags().Duration("dup-window-duration", time.Duration(5*time.Minute), "How long a dispatched URL is remembered by the --dup-window-size window.")
+
+	// AWS Signature Version 4 flags
+	getCmd.PersistentFlags().StringSlice("aws-sigv4-hosts", []string{}, "Host patterns (e.g. *.s3.amazonaws.com) for which requests are signed with AWS Signature Version 4.")
+	getCmd.PersistentFlags().String("aws-access-key-id", "", "AWS access key ID used to sign requests to --aws-sigv4-hosts.")
+	getCmd.PersistentFlags().String("aws-secret-access-key", "", "AWS secret access key used to sign requests to --aws-sigv4-hosts.")
+	getCmd.PersistentFlags().String("aws-session-token", "", "Optional AWS session token used to sign requests to --aws-sigv4-hosts.")
+	getCmd.PersistentFlags().String("aws-region", "us-east-1", "AWS region used to sign requests to --aws-sigv4-hosts.")
+	getCmd.PersistentFlags().String("aws-service", "s3", "AWS service name used to sign requests to --aws-sigv4-hosts.")
 
 	// Rate limiting flags
 	getCmd.PersistentFlags().Bool("disable-rate-limit", false, "Disable the Token Bucket rate limiting.")
 	getCmd.PersistentFlags().Float64("rate-limit-capacity", 150, "Bucket capacity for each host.")
 	getCmd.PersistentFlags().Float64("rate-limit-refill-rate", 50, "Ideal requests per second for each host.")
 	getCmd.PersistentFlags().Duration("rate-limit-cleanup-frequency", time.Duration(5*time.Minute), "How often to run cleanup of stale buckets that are not accessed in the duration.")
+	getCmd.PersistentFlags().Duration("politeness-delay", 0, "Minimum delay between two consecutive requests to the same host, applied on top of the rate limiting. 0 disables it.")
+	getCmd.PersistentFlags().Float64("error-rate-threshold", 0, "Fraction (0-1) of failed fetches over the last --error-rate-window fetches above which the crawl is paused for --error-rate-backoff. 0 disables it.")
+	getCmd.PersistentFlags().Int("error-rate-window", 100, "Number of fetches used to compute the error rate.")
+	getCmd.PersistentFlags().Duration("error-rate-backoff", time.Duration(5*time.Minute), "How long the crawl is paused when the error rate exceeds --error-rate-threshold.")
 
 	// WARC flags
 	getCmd.PersistentFlags().String("warc-prefix", "ZENO", "Prefix to use when naming the WARC files.")
@@ -97,6 +138,13 @@ func getCMDsFlags(getCmd *cobra.Command) {
 	// Prometheus and metrics flags
 	getCmd.PersistentFlags().Bool("prometheus", false, "Export metrics in Prometheus format. (implies --api)")
 	getCmd.PersistentFlags().String("prometheus-prefix", "zeno_", "String used as a prefix for the exported Prometheus metrics.")
+	getCmd.PersistentFlags().String("host-progress-report", "", "File to write the per-host crawl progress to every 60 seconds, as one JSON line per host.")
+	getCmd.PersistentFlags().String("domain-graph", "", "File to write the host-to-host link graph to, as a source,target,weight CSV edge list. Links between pages of a same host are left out.")
+	getCmd.PersistentFlags().Duration("domain-graph-interval", 5*time.Minute, "How often the --domain-graph file is written.")
+	getCmd.PersistentFlags().String("results-db", "", "SQLite database to record every fetch in (crawl_results table: URL, status code, content type, size, hops, fetch time, SHA-256 and error for failed fetches), for ad hoc queries.")
+	getCmd.PersistentFlags().Int("results-db-batch-size", 1000, "Number of fetches inserted per transaction in the --results-db database.")
+	getCmd.PersistentFlags().Bool("headers-archive", false, "Record the response headers of every fetch as JSON lines in headers.ndjson in the job directory.")
+	getCmd.PersistentFlags().Int("headers-archive-buffer-size", 64*1024, "Size in bytes of the write buffer of the --headers-archive file.")
 
 	// Consul flags
 	getCmd.PersistentFlags().String("consul-address", "", "Consul address to use for service registration.")
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/internetarchive/Zeno/internal/pkg/config"
 	"github.com/internetarchive/Zeno/internal/pkg/controler"
@@ -10,20 +11,25 @@ import (
 
 var getURLCmd = &cobra.Command{
 	Use:   "url [URL...]",
-	Short: "Archive given URLs",
-	Args:  cobra.MinimumNArgs(1),
+	Short: "Archive given URLs, use - (or pipe them) to read newline-delimited URLs from stdin",
+	Args:  cobra.ArbitraryArgs,
 	PreRunE: func(_ *cobra.Command, args []string) error {
 		if cfg == nil {
 			return fmt.Errorf("viper config is nil")
 		}
 
-		if len(args) == 0 {
+		if len(args) == 0 && !stdinIsPipe() {
 			return fmt.Errorf("no URLs provided")
 		}
 
 		return nil
 	},
 	RunE: func(_ *cobra.Command, args []string) error {
+		// Seeds are read from stdin when it's piped and no URL is given
+		if len(args) == 0 {
+			args = []string{"-"}
+		}
+
 		for _, URL := range args {
 			config.Get().InputSeeds = append(config.Get().InputSeeds, URL)
 		}
@@ -38,3 +44,13 @@ var getURLCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// stdinIsPipe returns true if stdin is not a terminal, e.g. when Zeno is used in a Unix pipeline.
+func stdinIsPipe() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode()&os.ModeCharDevice == 0
+}
@@ -3,6 +3,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/internal/pkg/reactor"
 	"github.com/internetarchive/Zeno/internal/pkg/stats"
 )
 
@@ -32,6 +34,10 @@ func Start() error {
 			mux.Handle("/metrics", stats.PrometheusHandler())
 		}
 
+		mux.HandleFunc("/stats", statsHandler)
+		mux.HandleFunc("POST /drain", drainHandler)
+		mux.HandleFunc("POST /drain/end", endDrainHandler)
+
 		server = &http.Server{
 			Addr:    ":" + strconv.Itoa(config.Get().APIPort),
 			Handler: mux,
@@ -55,6 +61,52 @@ func Start() error {
 	return nil
 }
 
+// topHostsCount is the number of hosts listed in the per-host sections of /stats
+const topHostsCount = 10
+
+// statsHandler returns the current crawl stats as JSON.
+func statsHandler(w http.ResponseWriter, _ *http.Request) {
+	response := stats.GetMapTUI()
+	response["Max total URLs"] = config.Get().MaxTotalURLs
+	response["Bytes archived"] = stats.BytesArchivedGet()
+	response["Max total bytes"] = config.Get().MaxTotalBytes
+	response["Draining"] = reactor.IsDraining()
+	response["Top hosts by RPS"] = stats.HostRPSTop(topHostsCount)
+	if config.Get().DomainGraph != "" {
+		response["Top inter-domain links"] = stats.DomainGraphTop(topHostsCount)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// drainHandler stops the crawl from accepting new seeds, the seeds already in the reactor
+// keep being processed. The progress is visible in /stats. Draining only covers the reactor
+// state table: the seeds the sources already claimed from the queue are held until the drain
+// ends, they aren't put back in the queue.
+func drainHandler(w http.ResponseWriter, _ *http.Request) {
+	if reactor.IsDraining() {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	go func() {
+		if err := reactor.Drain(context.Background()); err != nil {
+			log.Printf("Drain error: %v", err)
+		}
+	}()
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// endDrainHandler makes the crawl accept new seeds again.
+func endDrainHandler(w http.ResponseWriter, _ *http.Request) {
+	reactor.EndDrain()
+	w.WriteHeader(http.StatusOK)
+}
+
 // Stop gracefully shuts down the server within the provided timeout.
 func Stop(timeout time.Duration) error {
 	log.Printf("Stopping API server on %s", server.Addr)
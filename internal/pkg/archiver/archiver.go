@@ -40,15 +40,22 @@ type archiver struct {
 }
 
 var (
-	globalArchiver      *archiver
-	globalBucketManager *ratelimiter.BucketManager
-	once                sync.Once
-	logger              *log.FieldedLogger
+	globalArchiver          *archiver
+	globalBucketManager     *ratelimiter.BucketManager
+	globalPolitenessManager *ratelimiter.PolitenessManager
+	globalScheduleManager   *ratelimiter.ScheduleManager
+	globalConnPool          *PerHostConnPool
+	globalLatencyLimiter    *latencyLimiter
+	once                    sync.Once
+	logger                  *log.FieldedLogger
 )
 
 // Start initializes the internal archiver structure, start the WARC writer and start routines, should only be called once and returns an error if called more than once
 func Start(inputChan, outputChan chan *models.Item) error {
-	var done bool
+	var (
+		done bool
+		err  error
+	)
 
 	log.Start()
 	logger = log.NewFieldedLogger(&log.Fields{
@@ -74,6 +81,30 @@ func Start(inputChan, outputChan chan *models.Item) error {
 			)
 			logger.Info("bucket manager started")
 		}
+		if config.Get().PolitenessDelay > 0 {
+			globalPolitenessManager = ratelimiter.NewPolitenessManager(config.Get().PolitenessDelay)
+			logger.Info("politeness delay enabled", "delay", config.Get().PolitenessDelay.String())
+		}
+		if config.Get().MaxConnsPerHost > 0 {
+			globalConnPool = NewPerHostConnPool(config.Get().MaxConnsPerHost)
+			logger.Info("per-host connections limit enabled", "max_conns_per_host", config.Get().MaxConnsPerHost)
+		}
+		if len(config.Get().CrawlScheduleWindows) > 0 {
+			var hostWindows map[string][]ratelimiter.ScheduleWindow
+			hostWindows, err = ratelimiter.ParseHostScheduleWindows(config.Get().CrawlScheduleWindows)
+			if err != nil {
+				return
+			}
+			globalScheduleManager = ratelimiter.NewScheduleManager(ctx, hostWindows, 1*time.Minute)
+			logger.Info("crawl schedule windows enabled", "hosts", len(hostWindows))
+		}
+		if config.Get().HighLatencyThresholdMs > 0 {
+			globalLatencyLimiter = newLatencyLimiter(config.Get().MaxConcurrentAssets,
+				time.Duration(config.Get().HighLatencyThresholdMs)*time.Millisecond,
+				time.Duration(config.Get().LowLatencyThresholdMs)*time.Millisecond,
+			)
+			logger.Info("latency-based concurrency limiter enabled", "high_latency_threshold_ms", config.Get().HighLatencyThresholdMs, "low_latency_threshold_ms", config.Get().LowLatencyThresholdMs)
+		}
 		logger.Debug("initialized")
 
 		// Setup WARC writing HTTP clients
@@ -90,6 +121,10 @@ func Start(inputChan, outputChan chan *models.Item) error {
 		done = true
 	})
 
+	if err != nil {
+		return err
+	}
+
 	if !done {
 		return ErrArchiverAlreadyInitialized
 	}
@@ -190,8 +225,13 @@ func archive(workerID string, seed *models.Item) {
 		"worker_id": workerID,
 	})
 
+	maxConcurrentAssets := config.Get().MaxConcurrentAssets
+	if globalLatencyLimiter != nil {
+		maxConcurrentAssets = globalLatencyLimiter.concurrency()
+	}
+
 	var (
-		guard = make(chan struct{}, config.Get().MaxConcurrentAssets)
+		guard = make(chan struct{}, maxConcurrentAssets)
 		wg    sync.WaitGroup
 	)
 
@@ -207,13 +247,19 @@ func archive(workerID string, seed *models.Item) {
 			continue
 		}
 
+		// Don't fetch hosts outside of their crawl schedule windows, before taking any worker or limiter slot
+		if globalScheduleManager != nil && !globalScheduleManager.IsOpen(items[i].GetURL().GetParsed().Hostname()) {
+			logger.Info("host outside of its crawl schedule windows, skipping item", "seed_id", seed.GetShortID(), "item_id", items[i].GetShortID(), "depth", items[i].GetDepth(), "url", items[i].GetURL().String())
+			items[i].SetStatus(models.ItemFailed)
+			continue
+		}
+
 		guard <- struct{}{}
 
 		wg.Add(1)
 		go func(item *models.Item) {
 			defer wg.Done()
 			defer func() { <-guard }()
-			defer stats.URLsCrawledIncr()
 
 			var (
 				err          error
@@ -233,6 +279,31 @@ func archive(workerID string, seed *models.Item) {
 				logger.Debug("got token from bucket", "seed_id", seed.GetShortID(), "item_id", item.GetShortID(), "depth", item.GetDepth(), "hops", item.GetURL().GetHops(), "elapsed", elapsed)
 			}
 
+			// Wait for the politeness delay if enabled
+			if globalPolitenessManager != nil {
+				elapsed, err := globalPolitenessManager.Wait(globalArchiver.ctx, req.URL.Host)
+				if err != nil {
+					logger.Debug("aborting item waiting for the politeness delay due to stop", "seed_id", seed.GetShortID(), "item_id", item.GetShortID(), "depth", item.GetDepth(), "hops", item.GetURL().GetHops())
+					item.SetStatus(models.ItemFailed)
+					return
+				}
+				logger.Debug("waited for politeness delay", "seed_id", seed.GetShortID(), "item_id", item.GetShortID(), "depth", item.GetDepth(), "hops", item.GetURL().GetHops(), "elapsed", elapsed)
+			}
+
+			// Take a connection slot for the host until the body is processed, whatever the outcome
+			if globalConnPool != nil {
+				release, err := globalConnPool.Acquire(globalArchiver.ctx, req.URL.Host)
+				if err != nil {
+					logger.Debug("aborting item waiting for a connection slot due to stop", "seed_id", seed.GetShortID(), "item_id", item.GetShortID(), "depth", item.GetDepth(), "hops", item.GetURL().GetHops())
+					item.SetStatus(models.ItemFailed)
+					return
+				}
+				defer release()
+			}
+
+			// The item is counted as crawled once it isn't waiting anymore to be fetched
+			defer stats.URLsCrawledIncr()
+
 			// Don't use the global bucket manager in the retry loop.
 			// Most failed requests won't reach the server anyway, so we don't need to wait for the rate limit.
 			// This prevents workers from being blocked for too long by dead sites, such as host unreachable or DNS errors.
@@ -266,6 +337,9 @@ func archive(workerID string, seed *models.Item) {
 					// retries exhausted
 					logger.Error("unable to execute request", "err", err.Error(), "seed_id", seed.GetShortID(), "item_id", item.GetShortID(), "depth", item.GetDepth(), "hops", item.GetURL().GetHops())
 					item.SetStatus(models.ItemFailed)
+					stats.HostProgressFailedIncr(req.URL.Host)
+					stats.FetchErrorsAdd(true)
+					recordFailure(item.GetURL(), nil, err)
 					return
 				}
 
@@ -280,18 +354,23 @@ func archive(workerID string, seed *models.Item) {
 						logger.Warn("bad response code, retrying", "seed_id", seed.GetShortID(), "item_id", item.GetShortID(), "depth", item.GetDepth(), "hops", item.GetURL().GetHops(), "retry", retry, "sleep_time", retrySleepTime.String(), "status_code", resp.StatusCode, "url", req.URL.String())
 
 						// Consume body, needed to avoid leaking RAM & storage
-						io.Copy(io.Discard, resp.Body)
+						discarded, _ := io.Copy(io.Discard, resp.Body)
 						resp.Body.Close()
+						stats.BytesDownloadedAdd(uint64(discarded))
 
 						time.Sleep(retrySleepTime)
 						continue
 					} else {
 						logger.Error("bad response code, retries exceeded", "seed_id", seed.GetShortID(), "item_id", item.GetShortID(), "depth", item.GetDepth(), "hops", item.GetURL().GetHops(), "status_code", resp.StatusCode, "url", req.URL.String())
 						item.SetStatus(models.ItemFailed)
+						stats.HostProgressFailedIncr(req.URL.Host)
+						stats.FetchErrorsAdd(true)
+						recordFailure(item.GetURL(), resp, fmt.Errorf("bad response code %d, retries exceeded", resp.StatusCode))
 
 						// Consume body, needed to avoid leaking RAM & storage
-						io.Copy(io.Discard, resp.Body)
+						discarded, _ := io.Copy(io.Discard, resp.Body)
 						resp.Body.Close()
+						stats.BytesDownloadedAdd(uint64(discarded))
 
 						return
 					}
@@ -303,6 +382,9 @@ func archive(workerID string, seed *models.Item) {
 
 				// OK
 				stats.MeanHTTPRespTimeAdd(time.Since(getStartTime))
+				if globalLatencyLimiter != nil {
+					globalLatencyLimiter.observe(time.Since(getStartTime))
+				}
 				break
 			}
 
@@ -311,10 +393,18 @@ func archive(workerID string, seed *models.Item) {
 
 			// Process the body and measure the time
 			processStartTime := time.Now()
-			err = ProcessBody(item.GetURL(), config.Get().DisableAssetsCapture, domainscrawl.Enabled(), config.Get().MaxHops, config.Get().WARCTempDir)
+			bodySize, err := processBody(item.GetURL(), config.Get().DisableAssetsCapture, domainscrawl.Enabled(), config.Get().MaxHops, config.Get().WARCTempDir)
+
+			// The politeness delay starts once the body has been read
+			if globalPolitenessManager != nil {
+				globalPolitenessManager.Done(req.URL.Host)
+			}
 			if err != nil {
 				logger.Error("unable to process body", "err", err.Error(), "item_id", item.GetShortID(), "seed_id", seed.GetShortID(), "depth", item.GetDepth(), "hops", item.GetURL().GetHops())
 				item.SetStatus(models.ItemFailed)
+				stats.HostProgressFailedIncr(req.URL.Host)
+				stats.FetchErrorsAdd(true)
+				recordFailure(item.GetURL(), resp, err)
 				return
 			}
 
@@ -331,6 +421,15 @@ func archive(workerID string, seed *models.Item) {
 
 			logger.Info("url archived", "url", item.GetURL().String(), "seed_id", seed.GetShortID(), "item_id", item.GetShortID(), "depth", item.GetDepth(), "hops", item.GetURL().GetHops(), "status", resp.StatusCode)
 
+			// The bytes actually read count as archived, whether the response announced a Content-Length or not
+			stats.HostProgressFetchedIncr(req.URL.Host, uint64(bodySize))
+			stats.BytesArchivedAdd(uint64(bodySize))
+			stats.BytesDownloadedAdd(uint64(bodySize))
+			stats.HostRPSIncr(req.URL.Host)
+			recordResult(item.GetURL(), resp, bodySize)
+			recordHeaders(item.GetURL(), resp)
+			stats.FetchErrorsAdd(false)
+
 			item.SetStatus(models.ItemArchived)
 		}(items[i])
 	}
@@ -3,7 +3,9 @@ package archiver
 import (
 	"bytes"
 	"io"
+	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/CorentinB/warc/pkg/spooledtempfile"
@@ -15,29 +17,74 @@ import (
 
 // ProcessBody processes the body of a URL response, loading it into memory or a temporary file
 func ProcessBody(u *models.URL, disableAssetsCapture, domainsCrawl bool, maxHops int, WARCTempDir string) error {
+	_, err := processBody(u, disableAssetsCapture, domainsCrawl, maxHops, WARCTempDir)
+	return err
+}
+
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// processBody is ProcessBody, also returning the number of bytes read from the body
+func processBody(u *models.URL, disableAssetsCapture, domainsCrawl bool, maxHops int, WARCTempDir string) (read int64, err error) {
 	defer u.GetResponse().Body.Close() // Ensure the response body is closed
 
+	// The headers are received, limit the time spent reading the whole body by closing it
+	// once --http-body-timeout is elapsed, which also interrupts a blocked read
+	if config.Get() != nil && config.Get().HTTPBodyTimeout > 0 {
+		var timedOut atomic.Bool
+		timer := time.AfterFunc(time.Duration(config.Get().HTTPBodyTimeout)*time.Second, func() {
+			timedOut.Store(true)
+			u.GetResponse().Body.Close()
+		})
+		defer timer.Stop()
+
+		defer func() {
+			if err != nil && timedOut.Load() {
+				err = ErrBodyTimeout
+			}
+		}()
+	}
+
 	// Retrieve the underlying TCP connection and apply a 10s read deadline
 	conn, ok := u.GetResponse().Body.(interface{ SetReadDeadline(time.Time) error })
 	if ok {
 		err := conn.SetReadDeadline(time.Now().Add(time.Duration(config.Get().HTTPReadDeadline)))
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
 
+	// HTML pages announcing a body bigger than --html-size-limit are truncated:
+	// only the first bytes are read (and archived), then the body is closed
+	var body io.Reader = u.GetResponse().Body
+	if limit := htmlSizeLimit(u.GetResponse()); limit > 0 {
+		body = io.LimitReader(u.GetResponse().Body, limit)
+	}
+
+	counter := &countingReader{reader: body}
+	body = counter
+
 	// If we are not capturing assets, not extracting outlinks, and domains crawl is disabled
 	// we can just consume and discard the body
 	if disableAssetsCapture && !domainsCrawl && maxHops == 0 {
-		if err := copyWithTimeout(io.Discard, u.GetResponse().Body, conn); err != nil {
-			return err
+		if err := copyWithTimeout(io.Discard, body, conn); err != nil {
+			return counter.count, err
 		}
 	}
 
 	// Create a buffer to hold the body (first 2KB)
 	buffer := new(bytes.Buffer)
-	if err := copyWithTimeoutN(buffer, u.GetResponse().Body, 2048, conn); err != nil {
-		return err
+	if err := copyWithTimeoutN(buffer, body, 2048, conn); err != nil {
+		return counter.count, err
 	}
 
 	// Detect and set MIME type
@@ -56,30 +103,45 @@ func ProcessBody(u *models.URL, disableAssetsCapture, domainsCrawl bool, maxHops
 			if closeErr != nil {
 				panic(closeErr)
 			}
-			return err
+			return counter.count, err
 		}
 
 		// Read the rest of the body into the spooled buffer
-		if err := copyWithTimeout(spooledBuff, u.GetResponse().Body, conn); err != nil {
+		if err := copyWithTimeout(spooledBuff, body, conn); err != nil {
 			closeErr := spooledBuff.Close()
 			if closeErr != nil {
 				panic(closeErr)
 			}
-			return err
+			return counter.count, err
 		}
 
 		u.SetBody(spooledBuff)
 		u.RewindBody()
 
-		return nil
+		return counter.count, nil
 	} else {
 		// Read the rest of the body but discard it
-		if err := copyWithTimeout(io.Discard, u.GetResponse().Body, conn); err != nil {
-			return err
+		if err := copyWithTimeout(io.Discard, body, conn); err != nil {
+			return counter.count, err
 		}
 	}
 
-	return nil
+	return counter.count, nil
+}
+
+// htmlSizeLimit returns the number of bytes to read from the response if it's an HTML page
+// with a Content-Length above --html-size-limit, or 0 if the whole body should be read.
+func htmlSizeLimit(resp *http.Response) int64 {
+	if config.Get() == nil || config.Get().HTMLSizeLimit == 0 {
+		return 0
+	}
+
+	limit := int64(config.Get().HTMLSizeLimit)
+	if resp.ContentLength <= limit || !strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		return 0
+	}
+
+	return limit
 }
 
 // copyWithTimeout copies data and resets the read deadline after each successful read
@@ -0,0 +1,176 @@
+package archiver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func TestProcessBodyHTMLSizeLimit(t *testing.T) {
+	if err := config.InitConfig(); err != nil {
+		t.Fatal(err)
+	}
+	config.Get().HTMLSizeLimit = 4096
+	defer func() { config.Get().HTMLSizeLimit = 0 }()
+
+	html := "<html><body>" + strings.Repeat("a", 10000) + "</body></html>"
+
+	tests := []struct {
+		name        string
+		contentType string
+		wantSize    int
+	}{
+		{name: "oversized HTML is truncated", contentType: "text/html", wantSize: 4096},
+		{name: "other content types are not truncated", contentType: "text/plain", wantSize: len(html)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header:        http.Header{"Content-Type": []string{tt.contentType}},
+				ContentLength: int64(len(html)),
+				Body:          io.NopCloser(bytes.NewBufferString(html)),
+			}
+
+			URL := &models.URL{Raw: "https://example.com"}
+			URL.SetResponse(resp)
+
+			if err := ProcessBody(URL, false, false, 0, t.TempDir()); err != nil {
+				t.Fatalf("ProcessBody() error = %v", err)
+			}
+
+			body, err := io.ReadAll(URL.GetBody())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(body) != tt.wantSize {
+				t.Errorf("expected body of %d bytes, got %d", tt.wantSize, len(body))
+			}
+		})
+	}
+}
+
+func TestProcessBodyBytesRead(t *testing.T) {
+	if err := config.InitConfig(); err != nil {
+		t.Fatal(err)
+	}
+	config.Get().HTMLSizeLimit = 4096
+	defer func() { config.Get().HTMLSizeLimit = 0 }()
+
+	html := "<html><body>" + strings.Repeat("a", 10000) + "</body></html>"
+
+	tests := []struct {
+		name          string
+		contentType   string
+		contentLength int64
+		wantRead      int64
+	}{
+		{name: "unknown length (chunked) response", contentType: "text/html", contentLength: -1, wantRead: int64(len(html))},
+		{name: "truncated HTML counts the bytes read", contentType: "text/html", contentLength: int64(len(html)), wantRead: 4096},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header:        http.Header{"Content-Type": []string{tt.contentType}},
+				ContentLength: tt.contentLength,
+				Body:          io.NopCloser(bytes.NewBufferString(html)),
+			}
+
+			URL := &models.URL{Raw: "https://example.com"}
+			URL.SetResponse(resp)
+
+			read, err := processBody(URL, false, false, 0, t.TempDir())
+			if err != nil {
+				t.Fatalf("processBody() error = %v", err)
+			}
+
+			if read != tt.wantRead {
+				t.Errorf("expected %d bytes read, got %d", tt.wantRead, read)
+			}
+		})
+	}
+}
+
+func TestProcessBodyTimeout(t *testing.T) {
+	if err := config.InitConfig(); err != nil {
+		t.Fatal(err)
+	}
+	config.Get().HTTPBodyTimeout = 1
+	defer func() { config.Get().HTTPBodyTimeout = 0 }()
+
+	// The server sends the body slowly, each read is fast but the whole body never ends
+	bodyReader, bodyWriter := io.Pipe()
+	go func() {
+		for {
+			if _, err := bodyWriter.Write([]byte("a")); err != nil {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	resp := &http.Response{
+		Header:        http.Header{"Content-Type": []string{"text/plain"}},
+		ContentLength: -1,
+		Body:          bodyReader,
+	}
+
+	URL := &models.URL{Raw: "https://example.com"}
+	URL.SetResponse(resp)
+
+	start := time.Now()
+	if _, err := processBody(URL, false, false, 0, t.TempDir()); !errors.Is(err, ErrBodyTimeout) {
+		t.Fatalf("expected ErrBodyTimeout, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("expected the body read to be cut off after about 1s, took %s", elapsed)
+	}
+}
+
+func TestProcessBodyNoTimeout(t *testing.T) {
+	if err := config.InitConfig(); err != nil {
+		t.Fatal(err)
+	}
+	config.Get().HTTPBodyTimeout = 0
+
+	// The body is sent slowly over more than a second, it must be read entirely
+	body := strings.Repeat("a", 120)
+	bodyReader, bodyWriter := io.Pipe()
+	go func() {
+		for i := range body {
+			if _, err := bodyWriter.Write([]byte{body[i]}); err != nil {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		bodyWriter.Close()
+	}()
+
+	resp := &http.Response{
+		Header:        http.Header{"Content-Type": []string{"text/plain"}},
+		ContentLength: -1,
+		Body:          bodyReader,
+	}
+
+	URL := &models.URL{Raw: "https://example.com"}
+	URL.SetResponse(resp)
+
+	read, err := processBody(URL, false, false, 0, t.TempDir())
+	if err != nil {
+		t.Fatalf("processBody() error = %v", err)
+	}
+
+	if read != int64(len(body)) {
+		t.Errorf("expected %d bytes read, got %d", len(body), read)
+	}
+}
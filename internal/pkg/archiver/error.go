@@ -5,4 +5,6 @@ import "errors"
 var (
 	// ErrArchiverAlreadyInitialized is the error returned when the preprocess is already initialized
 	ErrArchiverAlreadyInitialized = errors.New("archiver already initialized")
+	// ErrBodyTimeout is the error returned when the body isn't read entirely within --http-body-timeout
+	ErrBodyTimeout = errors.New("body read timed out")
 )
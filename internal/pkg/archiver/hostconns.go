@@ -0,0 +1,39 @@
+package archiver
+
+import (
+	"context"
+	"sync"
+)
+
+// PerHostConnPool limits the number of concurrent connections to each host.
+// Keep-alives are disabled in the WARC-writing client, so each fetch uses its own connection:
+// the archiver acquires a slot before the request and releases it once the body is processed.
+type PerHostConnPool struct {
+	maxConnsPerHost int
+	hosts           sync.Map // map[string]chan struct{}
+}
+
+// NewPerHostConnPool returns a PerHostConnPool allowing maxConnsPerHost connections per host.
+func NewPerHostConnPool(maxConnsPerHost int) *PerHostConnPool {
+	return &PerHostConnPool{
+		maxConnsPerHost: maxConnsPerHost,
+	}
+}
+
+func (p *PerHostConnPool) semaphore(host string) chan struct{} {
+	sem, _ := p.hosts.LoadOrStore(host, make(chan struct{}, p.maxConnsPerHost))
+	return sem.(chan struct{})
+}
+
+// Acquire blocks until a connection slot is free for the host, or until the context is canceled.
+// The returned function releases the slot, it must be called exactly once.
+func (p *PerHostConnPool) Acquire(ctx context.Context, host string) (release func(), err error) {
+	sem := p.semaphore(host)
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,66 @@
+package archiver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPerHostConnPool(t *testing.T) {
+	pool := NewPerHostConnPool(2)
+
+	var (
+		open, maxOpen atomic.Int32
+		wg            sync.WaitGroup
+	)
+
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			release, err := pool.Acquire(context.Background(), "example.com")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer release()
+
+			n := open.Add(1)
+			for {
+				m := maxOpen.Load()
+				if n <= m || maxOpen.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			open.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	if maxOpen.Load() > 2 {
+		t.Errorf("expected at most 2 simultaneous connections, got %d", maxOpen.Load())
+	}
+
+	// Another host isn't limited by the connections to example.com
+	releaseA, _ := pool.Acquire(context.Background(), "example.com")
+	releaseB, _ := pool.Acquire(context.Background(), "example.com")
+	release, err := pool.Acquire(context.Background(), "example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	// Waiting for a full host stops with the context
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Acquire(ctx, "example.com"); err == nil {
+		t.Error("expected the wait for a full host to be canceled with the context")
+	}
+
+	releaseA()
+	releaseB()
+}
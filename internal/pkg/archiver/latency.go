@@ -0,0 +1,73 @@
+package archiver
+
+import (
+	"sync"
+	"time"
+)
+
+// latencyEMAAlpha is the weight of the newest fetch latency in the moving average
+const latencyEMAAlpha = 0.1
+
+// latencyLimiter adjusts the number of assets fetched concurrently per seed from an
+// exponential moving average of the fetch latency: it goes down by 1 (to a minimum of 1)
+// while the average is above the high threshold, and back up by 1 (to the configured
+// maximum) while it's below the low threshold. It changes at most once per cooldown.
+type latencyLimiter struct {
+	sync.Mutex
+	ema        float64 // in ms
+	current    int
+	max        int
+	high       float64 // in ms
+	low        float64 // in ms
+	cooldown   time.Duration
+	lastChange time.Time
+	nowFunc    func() time.Time
+}
+
+func newLatencyLimiter(maxConcurrency int, high, low time.Duration) *latencyLimiter {
+	return &latencyLimiter{
+		current:  maxConcurrency,
+		max:      maxConcurrency,
+		high:     float64(high.Milliseconds()),
+		low:      float64(low.Milliseconds()),
+		cooldown: time.Second,
+		nowFunc:  time.Now,
+	}
+}
+
+// observe adds the latency of a fetch to the moving average, and adjusts the concurrency if needed.
+func (l *latencyLimiter) observe(latency time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	ms := float64(latency.Milliseconds())
+	if l.ema == 0 {
+		l.ema = ms
+	} else {
+		l.ema = latencyEMAAlpha*ms + (1-latencyEMAAlpha)*l.ema
+	}
+
+	now := l.nowFunc()
+	if now.Sub(l.lastChange) < l.cooldown {
+		return
+	}
+
+	switch {
+	case l.ema > l.high && l.current > 1:
+		l.current--
+		l.lastChange = now
+		logger.Debug("fetch latency is high, reducing concurrent assets", "latency_ema_ms", l.ema, "max_concurrent_assets", l.current)
+	case l.ema < l.low && l.current < l.max:
+		l.current++
+		l.lastChange = now
+		logger.Debug("fetch latency is low, restoring concurrent assets", "latency_ema_ms", l.ema, "max_concurrent_assets", l.current)
+	}
+}
+
+// concurrency returns the number of assets to fetch concurrently for a seed.
+func (l *latencyLimiter) concurrency() int {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.current
+}
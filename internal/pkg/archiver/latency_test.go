@@ -0,0 +1,50 @@
+package archiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+)
+
+func TestLatencyLimiter(t *testing.T) {
+	logger = log.NewFieldedLogger(&log.Fields{"component": "archiver.test"})
+
+	now := time.Now()
+	l := newLatencyLimiter(4, 1000*time.Millisecond, 200*time.Millisecond)
+	l.nowFunc = func() time.Time { return now }
+
+	// Slow fetches reduce the concurrency by 1 per cooldown, down to 1
+	for i := 0; i < 10; i++ {
+		now = now.Add(l.cooldown)
+		l.observe(3 * time.Second)
+	}
+	if got := l.concurrency(); got != 1 {
+		t.Errorf("expected concurrency to go down to 1, got %d", got)
+	}
+
+	// Changes are limited to one per cooldown
+	l.observe(3 * time.Second)
+	if got := l.concurrency(); got != 1 {
+		t.Errorf("expected concurrency to stay at 1, got %d", got)
+	}
+
+	// Fast fetches restore the concurrency, up to the configured maximum
+	for i := 0; i < 100; i++ {
+		now = now.Add(l.cooldown)
+		l.observe(50 * time.Millisecond)
+	}
+	if got := l.concurrency(); got != 4 {
+		t.Errorf("expected concurrency to be restored to 4, got %d", got)
+	}
+
+	// Latencies between the thresholds don't change anything
+	l.ema = 500
+	for i := 0; i < 3; i++ {
+		now = now.Add(l.cooldown)
+		l.observe(500 * time.Millisecond)
+	}
+	if got := l.concurrency(); got != 4 {
+		t.Errorf("expected concurrency to stay at 4, got %d", got)
+	}
+}
@@ -0,0 +1,82 @@
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// hostSlot tracks the time from which the next request to a host is allowed.
+type hostSlot struct {
+	mu        sync.Mutex
+	lastFetch time.Time // the last fetch's end, or the start reserved by the last caller of Wait
+}
+
+// PolitenessManager enforces a fixed delay between consecutive requests to the same host.
+// It is additive to the token buckets of the BucketManager.
+type PolitenessManager struct {
+	delay time.Duration
+	hosts sync.Map // map[string]*hostSlot
+
+	// nowFunc is used to fetch the current time; it defaults to time.Now,
+	// but can be overridden for testing.
+	nowFunc func() time.Time
+}
+
+// NewPolitenessManager creates a new PolitenessManager enforcing the given delay.
+func NewPolitenessManager(delay time.Duration) *PolitenessManager {
+	return &PolitenessManager{
+		delay:   delay,
+		nowFunc: time.Now,
+	}
+}
+
+func (pm *PolitenessManager) getSlot(host string) *hostSlot {
+	slot, _ := pm.hosts.LoadOrStore(host, &hostSlot{})
+	return slot.(*hostSlot)
+}
+
+// Wait blocks until the politeness delay for the host has elapsed since its last fetch, or
+// until the context is canceled. It reserves its start time first, so that concurrent
+// callers are spaced by the delay as well without waiting on each other.
+func (pm *PolitenessManager) Wait(ctx context.Context, host string) (time.Duration, error) {
+	start := pm.nowFunc()
+	slot := pm.getSlot(host)
+
+	slot.mu.Lock()
+	next := start
+	if !slot.lastFetch.IsZero() && slot.lastFetch.Add(pm.delay).After(next) {
+		next = slot.lastFetch.Add(pm.delay)
+	}
+	slot.lastFetch = next
+	slot.mu.Unlock()
+
+	wait := next.Sub(start)
+	if wait <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return pm.nowFunc().Sub(start), nil
+	case <-ctx.Done():
+		return pm.nowFunc().Sub(start), ctx.Err()
+	}
+}
+
+// Done records the end of a fetch for the host, once its body has been read: the next
+// request will have to wait for the politeness delay starting from now.
+func (pm *PolitenessManager) Done(host string) {
+	slot := pm.getSlot(host)
+
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+
+	// Don't move back the start reserved by a request waiting for its turn
+	if now := pm.nowFunc(); now.After(slot.lastFetch) {
+		slot.lastFetch = now
+	}
+}
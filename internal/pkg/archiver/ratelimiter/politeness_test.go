@@ -0,0 +1,82 @@
+package ratelimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPolitenessFirstRequestDoesNotWait(t *testing.T) {
+	pm := NewPolitenessManager(time.Second)
+
+	if elapsed, _ := pm.Wait(context.Background(), "example.com"); elapsed > 100*time.Millisecond {
+		t.Fatalf("expected first request to not wait, waited %s", elapsed)
+	}
+}
+
+func TestPolitenessDelayBetweenRequests(t *testing.T) {
+	delay := 200 * time.Millisecond
+	pm := NewPolitenessManager(delay)
+
+	pm.Wait(context.Background(), "example.com")
+	pm.Done("example.com")
+
+	if elapsed, _ := pm.Wait(context.Background(), "example.com"); elapsed < delay-20*time.Millisecond {
+		t.Fatalf("expected second request to wait about %s, waited %s", delay, elapsed)
+	}
+}
+
+func TestPolitenessHostsAreIndependent(t *testing.T) {
+	pm := NewPolitenessManager(time.Second)
+
+	pm.Wait(context.Background(), "host1")
+	pm.Done("host1")
+
+	if elapsed, _ := pm.Wait(context.Background(), "host2"); elapsed > 100*time.Millisecond {
+		t.Fatalf("expected request to another host to not wait, waited %s", elapsed)
+	}
+}
+
+func TestPolitenessConcurrentRequestsAreSpaced(t *testing.T) {
+	delay := 100 * time.Millisecond
+	pm := NewPolitenessManager(delay)
+
+	pm.Wait(context.Background(), "example.com")
+
+	// Two requests waiting for the same host are spaced by the delay, not released together
+	results := make(chan time.Duration, 2)
+	for range 2 {
+		go func() {
+			elapsed, _ := pm.Wait(context.Background(), "example.com")
+			results <- elapsed
+		}()
+	}
+
+	first, second := <-results, <-results
+	if first > second {
+		first, second = second, first
+	}
+
+	if second-first < delay-20*time.Millisecond {
+		t.Fatalf("expected concurrent requests to be spaced by about %s, got %s and %s", delay, first, second)
+	}
+}
+
+func TestPolitenessWaitIsCanceled(t *testing.T) {
+	pm := NewPolitenessManager(time.Hour)
+
+	pm.Wait(context.Background(), "example.com")
+	pm.Done("example.com")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := pm.Wait(ctx, "example.com"); err == nil {
+		t.Fatal("expected the wait to be canceled with the context")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the wait to stop with the context, waited %s", elapsed)
+	}
+}
@@ -0,0 +1,180 @@
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScheduleWindow is a range of hours, in local time, during which crawling is allowed.
+// A window with StartHour > EndHour spans midnight, it belongs to the day it starts on.
+type ScheduleWindow struct {
+	EveryDay  bool
+	DayOfWeek time.Weekday
+	StartHour int // inclusive, 0-23
+	EndHour   int // exclusive, 1-24
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseScheduleWindow parses a window written as DAY:START-END, where DAY is a three-letter
+// weekday (mon, tue, ...) or * for every day, e.g. "sat:0-24" or "*:22-6".
+func ParseScheduleWindow(s string) (window ScheduleWindow, err error) {
+	day, hours, found := strings.Cut(strings.ToLower(strings.TrimSpace(s)), ":")
+	if !found {
+		return window, fmt.Errorf("invalid schedule window %q: expected DAY:START-END", s)
+	}
+
+	if day == "*" {
+		window.EveryDay = true
+	} else if window.DayOfWeek, found = weekdays[day]; !found {
+		return window, fmt.Errorf("invalid schedule window %q: unknown day %q", s, day)
+	}
+
+	start, end, found := strings.Cut(hours, "-")
+	if !found {
+		return window, fmt.Errorf("invalid schedule window %q: expected START-END hours", s)
+	}
+
+	window.StartHour, err = strconv.Atoi(start)
+	if err != nil || window.StartHour < 0 || window.StartHour > 23 {
+		return window, fmt.Errorf("invalid schedule window %q: start hour must be between 0 and 23", s)
+	}
+
+	window.EndHour, err = strconv.Atoi(end)
+	if err != nil || window.EndHour < 1 || window.EndHour > 24 || window.EndHour == window.StartHour {
+		return window, fmt.Errorf("invalid schedule window %q: end hour must be between 1 and 24, and differ from the start hour", s)
+	}
+
+	return window, nil
+}
+
+// contains returns true if the time falls within the window.
+func (w ScheduleWindow) contains(t time.Time) bool {
+	hour := t.Hour()
+
+	if w.StartHour < w.EndHour {
+		return (w.EveryDay || t.Weekday() == w.DayOfWeek) && hour >= w.StartHour && hour < w.EndHour
+	}
+
+	// The window spans midnight: the evening part is on its day, the morning part on the next one
+	if hour >= w.StartHour {
+		return w.EveryDay || t.Weekday() == w.DayOfWeek
+	}
+
+	return hour < w.EndHour && (w.EveryDay || t.Weekday() == (w.DayOfWeek+1)%7)
+}
+
+// inSchedule returns true if the time falls within any of the windows.
+func inSchedule(windows []ScheduleWindow, t time.Time) bool {
+	for _, window := range windows {
+		if window.contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseHostScheduleWindows parses --crawl-schedule-window entries of the form
+// host=DAY:START-END into the windows of each lowercased hostname. A host can have
+// several windows by repeating it.
+func ParseHostScheduleWindows(entries []string) (map[string][]ScheduleWindow, error) {
+	hostWindows := make(map[string][]ScheduleWindow, len(entries))
+
+	for _, entry := range entries {
+		host, rawWindow, found := strings.Cut(entry, "=")
+		host = strings.ToLower(strings.TrimSpace(host))
+		if !found || host == "" {
+			return nil, fmt.Errorf("invalid crawl schedule window %q: must be host=DAY:START-END", entry)
+		}
+
+		window, err := ParseScheduleWindow(rawWindow)
+		if err != nil {
+			return nil, err
+		}
+
+		hostWindows[host] = append(hostWindows[host], window)
+	}
+
+	return hostWindows, nil
+}
+
+// ScheduleManager tracks which of the hosts that have crawl schedule windows are outside of
+// all of them. The hosts without windows are always open.
+type ScheduleManager struct {
+	windows map[string][]ScheduleWindow
+
+	mu     sync.Mutex
+	closed map[string]bool
+
+	// nowFunc is used to fetch the current time; it defaults to time.Now,
+	// but can be overridden for testing.
+	nowFunc func() time.Time
+}
+
+// NewScheduleManager creates a ScheduleManager for the windows of each host. It checks
+// the schedule right away, then at every interval until the context is canceled.
+func NewScheduleManager(ctx context.Context, windows map[string][]ScheduleWindow, interval time.Duration) *ScheduleManager {
+	sm := newScheduleManager(windows, time.Now)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sm.check()
+			}
+		}
+	}()
+
+	return sm
+}
+
+func newScheduleManager(windows map[string][]ScheduleWindow, nowFunc func() time.Time) *ScheduleManager {
+	sm := &ScheduleManager{
+		windows: windows,
+		closed:  make(map[string]bool),
+		nowFunc: nowFunc,
+	}
+
+	sm.check()
+
+	return sm
+}
+
+// check updates the closed state of every host with the current time.
+func (sm *ScheduleManager) check() {
+	now := sm.nowFunc()
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	for host, windows := range sm.windows {
+		sm.closed[host] = !inSchedule(windows, now)
+	}
+}
+
+// IsOpen returns true if the host can be fetched now, i.e. if it has no crawl schedule
+// windows or if it is within one of them.
+func (sm *ScheduleManager) IsOpen(host string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return !sm.closed[strings.ToLower(host)]
+}
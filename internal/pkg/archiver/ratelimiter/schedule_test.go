@@ -0,0 +1,101 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleWindow(t *testing.T) {
+	window, err := ParseScheduleWindow("Sat:8-20")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if window.EveryDay || window.DayOfWeek != time.Saturday || window.StartHour != 8 || window.EndHour != 20 {
+		t.Errorf("unexpected window: %+v", window)
+	}
+
+	window, err = ParseScheduleWindow("*:22-6")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !window.EveryDay || window.StartHour != 22 || window.EndHour != 6 {
+		t.Errorf("unexpected window: %+v", window)
+	}
+
+	for _, invalid := range []string{"sat", "xyz:1-2", "mon:1", "mon:24-2", "mon:3-25", "mon:5-5", "mon:a-b"} {
+		if _, err := ParseScheduleWindow(invalid); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestInSchedule(t *testing.T) {
+	// 2025-01-04 is a Saturday
+	at := func(day, hour int) time.Time { return time.Date(2025, 1, day, hour, 30, 0, 0, time.Local) }
+
+	weekend, _ := ParseScheduleWindow("sat:0-24")
+	nights, _ := ParseScheduleWindow("fri:22-6")
+	windows := []ScheduleWindow{weekend, nights}
+
+	tests := []struct {
+		name string
+		time time.Time
+		want bool
+	}{
+		{"saturday afternoon", at(4, 15), true},
+		{"friday evening", at(3, 23), true},
+		{"friday afternoon", at(3, 15), false},
+		{"saturday early morning, from the friday night window", at(4, 5), true},
+		{"sunday early morning", at(5, 5), false},
+		{"sunday afternoon", at(5, 15), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inSchedule(windows, tt.time); got != tt.want {
+				t.Errorf("inSchedule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHostScheduleWindows(t *testing.T) {
+	hostWindows, err := ParseHostScheduleWindows([]string{"Example.com=sat:0-24", "example.com=*:22-6", "example.org=mon:8-12"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(hostWindows["example.com"]) != 2 || len(hostWindows["example.org"]) != 1 {
+		t.Errorf("unexpected windows: %+v", hostWindows)
+	}
+
+	for _, invalid := range []string{"sat:0-24", "=sat:0-24", "example.com=sat", "example.com"} {
+		if _, err := ParseHostScheduleWindows([]string{invalid}); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestScheduleManagerIsOpen(t *testing.T) {
+	// 2025-01-04 is a Saturday
+	now := time.Date(2025, 1, 4, 15, 30, 0, 0, time.Local)
+
+	weekdays, _ := ParseScheduleWindow("mon:0-24")
+	sm := newScheduleManager(map[string][]ScheduleWindow{"closed.com": {weekdays}}, func() time.Time { return now })
+
+	if !sm.IsOpen("other.com") {
+		t.Error("expected a host without windows to be open")
+	}
+
+	if sm.IsOpen("CLOSED.com") {
+		t.Error("expected a host outside of its windows to be closed")
+	}
+
+	// Monday morning, the window opens
+	now = time.Date(2025, 1, 6, 9, 0, 0, 0, time.Local)
+	sm.check()
+
+	if !sm.IsOpen("closed.com") {
+		t.Error("expected the host to be open once its window opened")
+	}
+}
@@ -0,0 +1,78 @@
+package archiver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/internal/pkg/results"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// recordResult records the fetch in the --results-db database if enabled.
+// size is the number of bytes read from the body, the SHA-256 is only computed
+// when the body was kept for post-processing.
+func recordResult(u *models.URL, resp *http.Response, size int64) {
+	if config.Get().ResultsDB == "" {
+		return
+	}
+
+	result := results.Result{
+		URL:         u.String(),
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        size,
+		HopDepth:    u.GetHops(),
+		FetchedAt:   time.Now(),
+	}
+
+	if u.GetBody() != nil {
+		hasher := sha256.New()
+		_, err := io.Copy(hasher, u.GetBody())
+		u.RewindBody()
+		if err == nil {
+			result.SHA256 = hex.EncodeToString(hasher.Sum(nil))
+		}
+	}
+
+	results.Record(result)
+}
+
+// recordFailure records a failed fetch in the --results-db database if enabled.
+// resp is nil when no response was received.
+func recordFailure(u *models.URL, resp *http.Response, fetchErr error) {
+	if config.Get().ResultsDB == "" {
+		return
+	}
+
+	result := results.Result{
+		URL:       u.String(),
+		HopDepth:  u.GetHops(),
+		FetchedAt: time.Now(),
+		Error:     fetchErr.Error(),
+	}
+
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+		result.ContentType = resp.Header.Get("Content-Type")
+	}
+
+	results.Record(result)
+}
+
+// recordHeaders records the response headers in the --headers-archive file if enabled.
+func recordHeaders(u *models.URL, resp *http.Response) {
+	if !config.Get().HeadersArchive {
+		return
+	}
+
+	results.RecordHeaders(results.HeaderRecord{
+		URL:        u.String(),
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Timestamp:  time.Now(),
+	})
+}
@@ -0,0 +1,69 @@
+package archiver
+
+import (
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// emptyPayloadHash is the SHA-256 of an empty payload, Zeno never sends a request body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// AWSV4Signer is an http.RoundTripper signing the requests whose host matches one of the
+// configured patterns with AWS Signature Version 4, before handing them to the wrapped transport.
+type AWSV4Signer struct {
+	next         http.RoundTripper
+	signer       *v4.Signer
+	credentials  aws.Credentials
+	region       string
+	service      string
+	hostPatterns []string
+}
+
+// NewAWSV4Signer wraps the transport with an AWSV4Signer. Host patterns use the path.Match syntax, e.g. *.s3.amazonaws.com.
+func NewAWSV4Signer(next http.RoundTripper, credentials aws.Credentials, region, service string, hostPatterns []string) *AWSV4Signer {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &AWSV4Signer{
+		next:         next,
+		signer:       v4.NewSigner(),
+		credentials:  credentials,
+		region:       region,
+		service:      service,
+		hostPatterns: hostPatterns,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (s *AWSV4Signer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !s.matches(req.URL.Hostname()) {
+		return s.next.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request it is given
+	signed := req.Clone(req.Context())
+	signed.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+
+	if err := s.signer.SignHTTP(req.Context(), s.credentials, signed, emptyPayloadHash, s.service, s.region, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.next.RoundTrip(signed)
+}
+
+func (s *AWSV4Signer) matches(host string) bool {
+	host = strings.ToLower(host)
+	for _, pattern := range s.hostPatterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), host); matched {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,53 @@
+package archiver
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+type recordingTransport struct {
+	last *http.Request
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.last = req
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestAWSV4Signer(t *testing.T) {
+	transport := &recordingTransport{}
+	signer := NewAWSV4Signer(transport, aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, "us-east-1", "s3", []string{"*.s3.amazonaws.com"})
+
+	tests := []struct {
+		url        string
+		wantSigned bool
+	}{
+		{url: "https://bucket.s3.amazonaws.com/key", wantSigned: true},
+		{url: "https://example.com/key", wantSigned: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := signer.RoundTrip(req); err != nil {
+				t.Fatal(err)
+			}
+
+			authorization := transport.last.Header.Get("Authorization")
+			if signed := strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKID/"); signed != tt.wantSigned {
+				t.Errorf("expected signed=%v, got Authorization %q", tt.wantSigned, authorization)
+			}
+
+			if req.Header.Get("Authorization") != "" {
+				t.Error("expected the original request to be left untouched")
+			}
+		})
+	}
+}
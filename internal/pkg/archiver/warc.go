@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/CorentinB/warc"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/internetarchive/Zeno/internal/pkg/config"
 )
 
@@ -45,6 +46,7 @@ func startWARCWriter() {
 		DisableIPv4:         config.Get().DisableIPv4,
 		DisableIPv6:         config.Get().DisableIPv6,
 		IPv6AnyIP:           config.Get().IPv6AnyIP,
+		DialTimeout:         time.Duration(config.Get().HTTPDialTimeout) * time.Second,
 	}
 
 	// Instantiate WARC client
@@ -80,6 +82,19 @@ func startWARCWriter() {
 		}()
 	}
 
+	// Sign the requests to the configured hosts with AWS Signature Version 4
+	if len(config.Get().AWSSigV4Hosts) > 0 {
+		credentials := aws.Credentials{
+			AccessKeyID:     config.Get().AWSAccessKeyID,
+			SecretAccessKey: config.Get().AWSSecretAccessKey,
+			SessionToken:    config.Get().AWSSessionToken,
+		}
+
+		for _, client := range GetClients() {
+			client.Transport = NewAWSV4Signer(client.Transport, credentials, config.Get().AWSRegion, config.Get().AWSService, config.Get().AWSSigV4Hosts)
+		}
+	}
+
 	// Set the timeouts
 	if config.Get().HTTPTimeout > 0 {
 		if globalArchiver.Client != nil {
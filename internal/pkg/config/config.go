@@ -33,6 +33,12 @@ type Config struct {
 	UseSeencheck     bool
 
 	UserAgent              string   `mapstructure:"user-agent"`
+	CrawlOperatorEmail     string   `mapstructure:"crawl-operator-email"`
+	CrawlInfoURL           string   `mapstructure:"crawl-info-url"`
+	HostUserAgent          []string `mapstructure:"host-user-agent"`
+	HostUserAgents         map[string]string
+	URLMetadata            []string `mapstructure:"url-metadata"`
+	URLAnnotations         map[string]string
 	Cookies                string   `mapstructure:"cookies"`
 	WARCPrefix             string   `mapstructure:"warc-prefix"`
 	WARCOperator           string   `mapstructure:"warc-operator"`
@@ -60,34 +66,74 @@ type Config struct {
 	ExclusionFile          []string `mapstructure:"exclusion-file"`
 	WorkersCount           int      `mapstructure:"workers"`
 	MaxConcurrentAssets    int      `mapstructure:"max-concurrent-assets"`
+	HighLatencyThresholdMs int      `mapstructure:"high-latency-threshold-ms"`
+	LowLatencyThresholdMs  int      `mapstructure:"low-latency-threshold-ms"`
 	MaxHops                int      `mapstructure:"max-hops"`
+	MaxPathDepth           int      `mapstructure:"max-path-depth"`
+	FollowPagination       bool     `mapstructure:"follow-pagination"`
+	StayOnRegisteredDomain bool     `mapstructure:"stay-on-registered-domain"`
+	MaxPaginationDepth     int      `mapstructure:"max-pagination-depth"`
 	MaxRedirect            int      `mapstructure:"max-redirect"`
 	MaxRetry               int      `mapstructure:"max-retry"`
 	HTTPTimeout            int      `mapstructure:"http-timeout"`
 	HTTPReadDeadline       int      `mapstructure:"http-read-deadline"`
+	HTTPDialTimeout        int      `mapstructure:"http-dial-timeout"`
+	HTTPBodyTimeout        int      `mapstructure:"http-body-timeout"`
+	HTMLSizeLimit          uint64   `mapstructure:"html-size-limit"`
+	MaxConnsPerHost        int      `mapstructure:"max-conns-per-host"`
 	CrawlTimeLimit         int      `mapstructure:"crawl-time-limit"`
 	CrawlMaxTimeLimit      int      `mapstructure:"crawl-max-time-limit"`
+	CrawlScheduleWindows   []string `mapstructure:"crawl-schedule-window"`
+	RecrawlCron            string   `mapstructure:"recrawl-cron"`
+	RecrawlOverlap         string   `mapstructure:"recrawl-overlap"`
+	MaxTotalURLs           uint64   `mapstructure:"max-total-urls"`
+	MaxTotalBytes          uint64   `mapstructure:"max-total-bytes"`
+	JSONMaxDepth           int      `mapstructure:"json-max-depth"`
+	OutlinksCacheSize      int      `mapstructure:"outlinks-cache-size"`
 	MinSpaceRequired       float64  `mapstructure:"min-space-required"`
 	DomainsCrawl           []string `mapstructure:"domains-crawl"`
 	CaptureAlternatePages  bool     `mapstructure:"capture-alternate-pages"`
+	HonorMetaRobots        bool     `mapstructure:"honor-meta-robots"`
 	DisableLocalDedupe     bool     `mapstructure:"disable-local-dedupe"`
 	CertValidation         bool     `mapstructure:"cert-validation"`
 	DisableAssetsCapture   bool     `mapstructure:"disable-assets-capture"`
 	UseHQ                  bool     // Special field to check if HQ is enabled depending on the command called
 	HQRateLimitingSendBack bool     `mapstructure:"hq-rate-limiting-send-back"`
 
+	// Stagger the start of instances launched simultaneously
+	StartupJitter time.Duration `mapstructure:"startup-jitter"`
+
+	// Short-term duplicate window, skipping URLs dispatched twice in a short time
+	DupWindowSize     int           `mapstructure:"dup-window-size"`
+	DupWindowDuration time.Duration `mapstructure:"dup-window-duration"`
+
 	// Network
-	Proxy         string `mapstructure:"proxy"`
-	RandomLocalIP bool   `mapstructure:"random-local-ip"`
-	DisableIPv4   bool   `mapstructure:"disable-ipv4"`
-	DisableIPv6   bool   `mapstructure:"disable-ipv6"`
-	IPv6AnyIP     bool   `mapstructure:"ipv6-anyip"`
+	Proxy             string        `mapstructure:"proxy"`
+	RandomLocalIP     bool          `mapstructure:"random-local-ip"`
+	DisableIPv4       bool          `mapstructure:"disable-ipv4"`
+	DisableIPv6       bool          `mapstructure:"disable-ipv6"`
+	IPv6AnyIP         bool          `mapstructure:"ipv6-anyip"`
+	PingCheckNewHosts bool          `mapstructure:"ping-check-new-hosts"`
+	NetworkInterface  string        `mapstructure:"network-interface"`
+	DeadHostTTL       time.Duration `mapstructure:"dead-host-ttl"`
+
+	// AWS Signature Version 4
+	AWSSigV4Hosts      []string `mapstructure:"aws-sigv4-hosts"`
+	AWSAccessKeyID     string   `mapstructure:"aws-access-key-id"`
+	AWSSecretAccessKey string   `mapstructure:"aws-secret-access-key"`
+	AWSSessionToken    string   `mapstructure:"aws-session-token"`
+	AWSRegion          string   `mapstructure:"aws-region"`
+	AWSService         string   `mapstructure:"aws-service"`
 
 	// Rate limiting
 	DisableRateLimit          bool          `mapstructure:"disable-rate-limit"`
 	RateLimitCapacity         float64       `mapstructure:"rate-limit-capacity"`
 	RateLimitRefillRate       float64       `mapstructure:"rate-limit-refill-rate"`
 	RateLimitCleanupFrequency time.Duration `mapstructure:"rate-limit-cleanup-frequency"`
+	PolitenessDelay           time.Duration `mapstructure:"politeness-delay"`
+	ErrorRateThreshold        float64       `mapstructure:"error-rate-threshold"`
+	ErrorRateWindow           int           `mapstructure:"error-rate-window"`
+	ErrorRateBackoff          time.Duration `mapstructure:"error-rate-backoff"`
 
 	// Logging
 	NoStdoutLogging  bool   `mapstructure:"no-stdout-log"`
@@ -105,12 +151,22 @@ type Config struct {
 	PyroscopeAddress string `mapstructure:"pyroscope-address"`
 
 	// API
-	APIPort int  `mapstructure:"api-port"`
-	API     bool `mapstructure:"api"`
+	APIPort       int    `mapstructure:"api-port"`
+	API           bool   `mapstructure:"api"`
+	ControlSocket string `mapstructure:"control-socket"`
 
 	// Prometheus and metrics
-	Prometheus       bool   `mapstructure:"prometheus"`
-	PrometheusPrefix string `mapstructure:"prometheus-prefix"`
+	Prometheus         bool   `mapstructure:"prometheus"`
+	PrometheusPrefix   string `mapstructure:"prometheus-prefix"`
+	HostProgressReport string `mapstructure:"host-progress-report"`
+	ResultsDB          string `mapstructure:"results-db"`
+	ResultsDBBatchSize int    `mapstructure:"results-db-batch-size"`
+	HeadersArchive     bool   `mapstructure:"headers-archive"`
+	HeadersArchiveBuf  int    `mapstructure:"headers-archive-buffer-size"`
+
+	// Domain graph
+	DomainGraph         string        `mapstructure:"domain-graph"`
+	DomainGraphInterval time.Duration `mapstructure:"domain-graph-interval"`
 
 	// Consul
 	ConsulAddress      string   `mapstructure:"consul-address"`
@@ -242,14 +298,58 @@ func GenerateCrawlConfig() error {
 			version.Version = version.Version[:7]
 		}
 
-		config.UserAgent = "Mozilla/5.0 (compatible; archive.org_bot +http://archive.org/details/archive.org_bot) Zeno/" + version.Version + " warc/" + version.WarcVersion
+		// Identify the crawl operator if they gave a way to contact them
+		if config.CrawlInfoURL != "" || config.CrawlOperatorEmail != "" {
+			userAgent, err := operatorUserAgent(version.Version, config.CrawlInfoURL, config.CrawlOperatorEmail)
+			if err != nil {
+				slog.Error("unable to build the User-Agent", "error", err)
+				return err
+			}
+
+			config.UserAgent = userAgent
+		} else {
+			config.UserAgent = "Mozilla/5.0 (compatible; archive.org_bot +http://archive.org/details/archive.org_bot) Zeno/" + version.Version + " warc/" + version.WarcVersion
+		}
 		slog.Info("User-Agent set to", "user-agent", config.UserAgent)
 	}
 
+	if len(config.HostUserAgent) > 0 {
+		hostUserAgents, err := parseHostUserAgents(config.HostUserAgent)
+		if err != nil {
+			slog.Error("unable to parse --host-user-agent", "error", err)
+			return err
+		}
+
+		config.HostUserAgents = hostUserAgents
+	}
+
+	if len(config.URLMetadata) > 0 {
+		annotations, err := parseURLMetadata(config.URLMetadata)
+		if err != nil {
+			slog.Error("unable to parse --url-metadata", "error", err)
+			return err
+		}
+
+		config.URLAnnotations = annotations
+	}
+
 	if config.RandomLocalIP {
 		slog.Warn("Random local IP is enabled")
 	}
 
+	// Recrawls insert the input seeds again, they would be dropped by the seencheck
+	if config.RecrawlCron != "" {
+		if len(config.InputSeeds) == 0 {
+			slog.Error("--recrawl-cron requires seeds given to get url")
+			return fmt.Errorf("--recrawl-cron requires seeds given to get url")
+		}
+
+		if config.UseSeencheck {
+			slog.Error("--recrawl-cron requires --disable-seencheck, the recrawled URLs would be skipped as already seen")
+			return fmt.Errorf("--recrawl-cron requires --disable-seencheck")
+		}
+	}
+
 	if config.DisableIPv4 && config.DisableIPv6 {
 		slog.Error("Both IPv4 and IPv6 are disabled, at least one of them must be enabled.")
 		os.Exit(1)
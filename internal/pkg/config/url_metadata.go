@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseURLMetadata parses --url-metadata entries of the form key=value into a map
+func parseURLMetadata(entries []string) (map[string]string, error) {
+	annotations := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		key, value, found := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid --url-metadata %q: must be key=value", entry)
+		}
+
+		annotations[key] = strings.TrimSpace(value)
+	}
+
+	return annotations, nil
+}
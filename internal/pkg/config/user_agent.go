@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// operatorUserAgent builds a User-Agent identifying the crawl operator, following the
+// Googlebot and Heritrix convention: Zeno/VERSION (+crawlInfoURL; operator@email)
+func operatorUserAgent(version, crawlInfoURL, operatorEmail string) (string, error) {
+	var contacts []string
+
+	if crawlInfoURL != "" {
+		parsed, err := url.Parse(crawlInfoURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return "", fmt.Errorf("invalid --crawl-info-url %q: must be an http or https URL", crawlInfoURL)
+		}
+
+		contacts = append(contacts, "+"+crawlInfoURL)
+	}
+
+	if operatorEmail != "" {
+		address, err := mail.ParseAddress(operatorEmail)
+		if err != nil || address.Address != operatorEmail {
+			return "", fmt.Errorf("invalid --crawl-operator-email %q: must be a bare email address", operatorEmail)
+		}
+
+		contacts = append(contacts, operatorEmail)
+	}
+
+	return "Zeno/" + version + " (" + strings.Join(contacts, "; ") + ")", nil
+}
+
+// parseHostUserAgents parses --host-user-agent entries of the form host=user-agent into a
+// map keyed by lowercased hostname
+func parseHostUserAgents(entries []string) (map[string]string, error) {
+	hostUserAgents := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		host, userAgent, found := strings.Cut(entry, "=")
+		host = strings.ToLower(strings.TrimSpace(host))
+		userAgent = strings.TrimSpace(userAgent)
+		if !found || host == "" || userAgent == "" {
+			return nil, fmt.Errorf("invalid --host-user-agent %q: must be host=user-agent", entry)
+		}
+
+		hostUserAgents[host] = userAgent
+	}
+
+	return hostUserAgents, nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import "testing"
+
+func TestOperatorUserAgent(t *testing.T) {
+	tests := []struct {
+		name          string
+		crawlInfoURL  string
+		operatorEmail string
+		expected      string
+		wantErr       bool
+	}{
+		{"URL and email", "https://example.org/crawl", "crawler@example.org", "Zeno/v2.0.0 (+https://example.org/crawl; crawler@example.org)", false},
+		{"URL only", "https://example.org/crawl", "", "Zeno/v2.0.0 (+https://example.org/crawl)", false},
+		{"email only", "", "crawler@example.org", "Zeno/v2.0.0 (crawler@example.org)", false},
+		{"invalid URL scheme", "ftp://example.org/crawl", "", "", true},
+		{"relative URL", "/crawl", "", "", true},
+		{"invalid email", "", "crawler", "", true},
+		{"email with display name", "", "Crawler <crawler@example.org>", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userAgent, err := operatorUserAgent("v2.0.0", tt.crawlInfoURL, tt.operatorEmail)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %v, got %v", tt.wantErr, err)
+			}
+
+			if userAgent != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, userAgent)
+			}
+		})
+	}
+}
+
+func TestParseHostUserAgents(t *testing.T) {
+	hostUserAgents, err := parseHostUserAgents([]string{"Example.com=ExampleBot/1.0", "www.example.org = Mozilla/5.0 (compatible; a=b, c)"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if hostUserAgents["example.com"] != "ExampleBot/1.0" {
+		t.Errorf("unexpected User-Agent for example.com: %q", hostUserAgents["example.com"])
+	}
+
+	if hostUserAgents["www.example.org"] != "Mozilla/5.0 (compatible; a=b, c)" {
+		t.Errorf("unexpected User-Agent for www.example.org: %q", hostUserAgents["www.example.org"])
+	}
+
+	for _, invalid := range []string{"example.com", "=ExampleBot/1.0", "example.com="} {
+		if _, err := parseHostUserAgents([]string{invalid}); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
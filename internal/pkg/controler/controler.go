@@ -1,13 +1,80 @@
 // Package controler provides a way to start and stop the pipeline.
 package controler
 
+import (
+	"sync"
+
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/internal/pkg/controler/pause"
+	"github.com/internetarchive/Zeno/internal/pkg/controler/watchers"
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+)
+
+var (
+	stopOnce sync.Once
+	stopped  = make(chan struct{})
+)
+
 // Start initializes the pipeline.
 func Start() {
 	startPipeline()
+	startShutdownWatcher(StopWithReason)
 }
 
 // Stop stops the pipeline.
 func Stop() {
-	stopPipeline()
-	closeStageChannels()
+	StopWithReason("stop requested")
+}
+
+// StopWithReason stops the pipeline, the reason is written in the shutdown report of the job.
+// Only the first call stops the pipeline, the next ones wait for it to be stopped.
+func StopWithReason(reason string) {
+	stopOnce.Do(func() {
+		stopPipeline(reason)
+		closeStageChannels()
+		close(stopped)
+	})
+}
+
+// Stopped returns a channel that is closed once the pipeline is stopped, whatever
+// stopped it: a signal, the TUI or one of Zeno's own components.
+func Stopped() <-chan struct{} {
+	return stopped
+}
+
+// Pause pauses the whole pipeline, the reason is logged and exposed as the pause message.
+func Pause(reason string) {
+	logger := log.NewFieldedLogger(&log.Fields{
+		"component": "controler.Pause",
+	})
+
+	if pause.IsPaused() {
+		logger.Debug("pipeline already paused", "reason", pause.GetMessage())
+		return
+	}
+
+	logger.Warn("pausing the pipeline", "reason", reason)
+	pause.Pause(reason)
+}
+
+// Resume resumes the pipeline if it was paused. It returns false if the pipeline
+// cannot be resumed, e.g. because the disk is still full.
+func Resume() bool {
+	logger := log.NewFieldedLogger(&log.Fields{
+		"component": "controler.Resume",
+	})
+
+	if !pause.IsPaused() {
+		return true
+	}
+
+	if err := watchers.CheckDiskUsage(config.Get().JobPath); err != nil {
+		logger.Warn("unable to resume the pipeline", "err", err.Error())
+		return false
+	}
+
+	logger.Info("resuming the pipeline")
+	pause.Resume()
+
+	return true
 }
@@ -0,0 +1,118 @@
+package controler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/internal/pkg/finisher"
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+	"github.com/internetarchive/Zeno/internal/pkg/reactor"
+	"github.com/internetarchive/Zeno/internal/pkg/source/lq"
+	"github.com/internetarchive/Zeno/internal/pkg/stats"
+)
+
+var (
+	limitWatcherCtx, limitWatcherCancel = context.WithCancel(context.Background())
+	limitWatcherWg                      sync.WaitGroup
+)
+
+// startURLLimitWatcher waits for the --max-total-urls limit to be reached and for the
+// queue to be drained, then requests a graceful shutdown of Zeno.
+func startURLLimitWatcher(interval time.Duration) {
+	if config.Get().MaxTotalURLs == 0 {
+		return
+	}
+
+	limitWatcherWg.Add(1)
+	go func() {
+		defer limitWatcherWg.Done()
+
+		logger := log.NewFieldedLogger(&log.Fields{
+			"component": "controler.urlLimitWatcher",
+		})
+		defer logger.Debug("closed")
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// The queue has to be seen drained on two consecutive checks, so that URLs
+		// in flight between the source and the reactor aren't missed
+		drainedChecks := 0
+
+		for {
+			select {
+			case <-limitWatcherCtx.Done():
+				return
+			case <-ticker.C:
+				if !finisher.URLLimitReached() || len(reactor.GetStateTable()) > 0 || (!config.Get().UseHQ && !lq.IsEmpty()) {
+					drainedChecks = 0
+					continue
+				}
+
+				drainedChecks++
+				if drainedChecks < 2 {
+					continue
+				}
+
+				logger.Info("maximum number of enqueued URLs reached and queue drained, stopping", "limit", config.Get().MaxTotalURLs, "enqueued", stats.URLsEnqueuedGet())
+				requestShutdown("max-total-urls reached")
+				return
+			}
+		}
+	}()
+}
+
+// startByteLimitWatcher requests a graceful shutdown of Zeno once --max-total-bytes
+// have been archived. Stopping freezes the reactor, so no new URLs are taken from the queue.
+func startByteLimitWatcher(interval time.Duration) {
+	if config.Get().MaxTotalBytes == 0 {
+		return
+	}
+
+	limitWatcherWg.Add(1)
+	go func() {
+		defer limitWatcherWg.Done()
+
+		logger := log.NewFieldedLogger(&log.Fields{
+			"component": "controler.byteLimitWatcher",
+		})
+		defer logger.Debug("closed")
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-limitWatcherCtx.Done():
+				return
+			case <-ticker.C:
+				if stats.BytesArchivedGet() < config.Get().MaxTotalBytes {
+					continue
+				}
+
+				// Log what is left to crawl, the local queue can be counted but not HQ
+				args := []any{"limit", config.Get().MaxTotalBytes, "archived", stats.BytesArchivedGet(), "seeds_in_flight", len(reactor.GetStateTable())}
+				if !config.Get().UseHQ {
+					queued, err := lq.RemainingURLs()
+					if err != nil {
+						logger.Warn("unable to count the URLs remaining in the queue", "err", err.Error())
+					} else {
+						args = append(args, "queued_urls", queued)
+					}
+				}
+
+				logger.Info("maximum number of archived bytes reached, stopping", args...)
+				requestShutdown("max-total-bytes reached")
+				return
+			}
+		}
+	}()
+}
+
+// stopURLLimitWatcher stops the URL and byte limit watchers.
+func stopURLLimitWatcher() {
+	limitWatcherCancel()
+	limitWatcherWg.Wait()
+}
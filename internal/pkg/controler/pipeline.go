@@ -1,8 +1,11 @@
 package controler
 
 import (
+	"context"
 	"fmt"
+	"math/rand/v2"
 	"os"
+	"path"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,10 +16,13 @@ import (
 	"github.com/internetarchive/Zeno/internal/pkg/controler/watchers"
 	"github.com/internetarchive/Zeno/internal/pkg/finisher"
 	"github.com/internetarchive/Zeno/internal/pkg/log"
+	"github.com/internetarchive/Zeno/internal/pkg/metadata"
 	"github.com/internetarchive/Zeno/internal/pkg/postprocessor"
 	"github.com/internetarchive/Zeno/internal/pkg/preprocessor"
 	"github.com/internetarchive/Zeno/internal/pkg/preprocessor/seencheck"
 	"github.com/internetarchive/Zeno/internal/pkg/reactor"
+	"github.com/internetarchive/Zeno/internal/pkg/results"
+	"github.com/internetarchive/Zeno/internal/pkg/scheduler"
 	"github.com/internetarchive/Zeno/internal/pkg/source/hq"
 	"github.com/internetarchive/Zeno/internal/pkg/source/lq"
 	"github.com/internetarchive/Zeno/internal/pkg/stats"
@@ -44,15 +50,64 @@ func startPipeline() {
 		"component": "controler.StartPipeline",
 	})
 
+	// Stagger the start of instances launched simultaneously, before any of them is visible (API, Consul, output files)
+	if config.Get().StartupJitter > 0 {
+		jitter := rand.N(config.Get().StartupJitter)
+		logger.Info("waiting before starting the crawl", "startup_jitter", jitter.String())
+		time.Sleep(jitter)
+	}
+
 	err = stats.Init()
 	if err != nil {
 		logger.Error("error initializing stats", "err", err.Error())
 		panic(err)
 	}
 
+	// Start the per-host progress reporter if needed
+	if config.Get().HostProgressReport != "" {
+		stats.StartHostProgressReporter(config.Get().HostProgressReport, 60*time.Second)
+	}
+
+	// Start the domain graph writer if needed
+	if config.Get().DomainGraph != "" {
+		stats.StartDomainGraphWriter(config.Get().DomainGraph, config.Get().DomainGraphInterval)
+	}
+
+	// Start the fetch results database if needed
+	if config.Get().ResultsDB != "" {
+		err := results.Start(config.Get().ResultsDB, config.Get().ResultsDBBatchSize)
+		if err != nil {
+			logger.Error("unable to start results database", "err", err.Error())
+			panic(err)
+		}
+	}
+
+	// Start the response headers archive if needed
+	if config.Get().HeadersArchive {
+		err := results.StartHeaderArchive(path.Join(config.Get().JobPath, results.HeadersFileName), config.Get().HeadersArchiveBuf)
+		if err != nil {
+			logger.Error("unable to start headers archive", "err", err.Error())
+			panic(err)
+		}
+	}
+
+	// Open the URL metadata store if needed
+	if len(config.Get().URLAnnotations) > 0 {
+		err := metadata.Start(config.Get().JobPath)
+		if err != nil {
+			logger.Error("unable to open URL metadata store", "err", err.Error())
+			panic(err)
+		}
+	}
+
 	// Start the disk watcher
 	go watchers.WatchDiskSpace(config.Get().JobPath, 5*time.Second)
 
+	// Start the network interface watcher if needed
+	if config.Get().NetworkInterface != "" {
+		watchers.StartWatchNetworkInterface(config.Get().NetworkInterface, 5*time.Second)
+	}
+
 	// Start the API server if needed
 	if config.Get().API {
 		api.Start()
@@ -101,6 +156,9 @@ func startPipeline() {
 	// Start the WARC writing queue watcher
 	watchers.StartWatchWARCWritingQueue(1*time.Second, 2*time.Second, 250*time.Millisecond)
 
+	// Start the error rate watcher, backing off when too many fetches fail
+	watchers.StartWatchErrorRate(1 * time.Second)
+
 	postprocessorOutputChan := makeStageChannel(config.Get().WorkersCount)
 	err = postprocessor.Start(archiverOutputChan, postprocessorOutputChan)
 	if err != nil {
@@ -133,9 +191,54 @@ func startPipeline() {
 		panic(err)
 	}
 
-	// Pipe in the reactor the input seeds if any
+	// Start the watcher stopping Zeno once --max-total-urls is reached
+	startURLLimitWatcher(1 * time.Second)
+
+	// Start the watcher stopping Zeno once --max-total-bytes have been archived
+	startByteLimitWatcher(1 * time.Second)
+
+	// Start the control socket if needed, once all the stages are subscribed to pauses
+	if config.Get().ControlSocket != "" {
+		err := startControlSocket(config.Get().ControlSocket)
+		if err != nil {
+			logger.Error("unable to start control socket", "err", err.Error())
+			panic(err)
+		}
+	}
+
+	// Pipe in the reactor the input seeds if any, "-" means that seeds are read from stdin
 	if len(config.Get().InputSeeds) > 0 {
+		var inputSeeds []string
 		for _, seed := range config.Get().InputSeeds {
+			if seed != "-" {
+				inputSeeds = append(inputSeeds, seed)
+				continue
+			}
+
+			stdinSeeds, err := scheduler.SeedFromReader(os.Stdin)()
+			if err != nil {
+				logger.Error("unable to read seeds from stdin", "err", err.Error())
+				panic(err)
+			}
+
+			for _, stdinSeed := range stdinSeeds {
+				inputSeeds = append(inputSeeds, stdinSeed.String())
+			}
+		}
+
+		// Drop the duplicate seeds, and the ones crawled by previous runs when the local seencheck is used
+		var inventory func(*models.URL) bool
+		if config.Get().UseSeencheck && !config.Get().UseHQ {
+			inventory = seencheck.IsSeedSeen
+		}
+
+		inputSeeds, dups := seencheck.NewSeedDeduplicator(func(URL *models.URL) error { return preprocessor.NormalizeURL(URL, nil) }, inventory).Dedupe(inputSeeds)
+		if dups > 0 {
+			logger.Info("dropped duplicate seeds", "duplicates", dups, "seeds", len(inputSeeds))
+		}
+
+	insertSeeds:
+		for _, seed := range inputSeeds {
 			parsedURL := &models.URL{Raw: seed}
 			err := parsedURL.Parse()
 			if err != nil {
@@ -146,24 +249,49 @@ func startPipeline() {
 			item.SetSource(models.ItemSourceQueue)
 
 			err = reactor.ReceiveInsert(item)
+			for err == reactor.ErrReactorDraining {
+				// Hold the seeds until the reactor accepts new seeds again
+				if err := reactor.WaitDrainEnd(context.Background()); err != nil {
+					logger.Warn("stopped inserting seeds while waiting for the reactor to end draining", "err", err.Error())
+					break insertSeeds
+				}
+				err = reactor.ReceiveInsert(item)
+			}
 			if err != nil {
 				logger.Error("unable to insert seed", "err", err.Error())
 				panic(err)
 			}
 		}
+
+		// Insert the same seeds again on the --recrawl-cron schedule
+		if config.Get().RecrawlCron != "" {
+			if err := startRecrawlScheduler(inputSeeds); err != nil {
+				logger.Error("unable to start recrawl scheduler", "err", err.Error())
+				panic(err)
+			}
+		}
 	}
 }
 
-func stopPipeline() {
+func stopPipeline(reason string) {
 	logger := log.NewFieldedLogger(&log.Fields{
 		"component": "controler.stopPipeline",
 	})
 
+	stopStartTime := time.Now()
+	var shutdownErrors []string
+
 	watchers.StopDiskWatcher()
 	watchers.StopWARCWritingQueueWatcher()
+	watchers.StopErrorRateWatcher()
+	watchers.StopNetworkWatcher()
+	stopURLLimitWatcher()
 
 	reactor.Freeze()
 
+	// Once the reactor is frozen, an ongoing recrawl can't be blocked inserting seeds
+	stopRecrawlScheduler()
+
 	preprocessor.Stop()
 	archiver.Stop()
 	postprocessor.Stop()
@@ -181,21 +309,65 @@ func stopPipeline() {
 
 	reactor.Stop()
 
+	if config.Get().HostProgressReport != "" {
+		stats.StopHostProgressReporter()
+	}
+
+	if config.Get().DomainGraph != "" {
+		stats.StopDomainGraphWriter()
+	}
+
+	if config.Get().ResultsDB != "" {
+		results.Stop()
+	}
+
+	if config.Get().HeadersArchive {
+		results.StopHeaderArchive()
+	}
+
+	if len(config.Get().URLAnnotations) > 0 {
+		metadata.Stop()
+	}
+
 	if config.Get().WARCTempDir != "" {
 		err := os.Remove(config.Get().WARCTempDir)
 		if err != nil {
 			logger.Error("unable to remove temp dir", "err", err.Error())
+			shutdownErrors = append(shutdownErrors, "unable to remove temp dir: "+err.Error())
 		}
 	}
 
 	if config.Get().API {
-		api.Stop(5 * time.Second)
+		if err := api.Stop(5 * time.Second); err != nil {
+			logger.Error("unable to stop API server", "err", err.Error())
+			shutdownErrors = append(shutdownErrors, "unable to stop API server: "+err.Error())
+		}
+	}
+
+	if config.Get().ControlSocket != "" {
+		stopControlSocket()
 	}
 
 	if config.Get().ConsulRegister {
 		consul.Stop()
 	}
 
+	finalStats, err := crawlStats()
+	if err != nil {
+		logger.Warn("unable to count the URLs remaining in the queue", "err", err.Error())
+	}
+
+	err = writeShutdownReport(config.Get().JobPath, &ShutdownReport{
+		ExitReason:       reason,
+		StoppedAt:        time.Now(),
+		ShutdownDuration: time.Since(stopStartTime),
+		FinalStats:       finalStats,
+		Errors:           shutdownErrors,
+	})
+	if err != nil {
+		logger.Error("unable to write shutdown report", "err", err.Error())
+	}
+
 	logger.Info("done, logs are flushing and will be closed")
 
 	log.Stop()
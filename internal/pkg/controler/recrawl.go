@@ -0,0 +1,45 @@
+package controler
+
+import (
+	"net/url"
+
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/internal/pkg/scheduler"
+)
+
+var recrawlScheduler *scheduler.CrawlScheduler
+
+// startRecrawlScheduler inserts the seeds in the reactor again at each time of the
+// --recrawl-cron schedule, following the --recrawl-overlap policy.
+func startRecrawlScheduler(seeds []string) error {
+	policy, err := scheduler.ParseOverlapPolicy(config.Get().RecrawlOverlap)
+	if err != nil {
+		return err
+	}
+
+	recrawlScheduler, err = scheduler.New(config.Get().RecrawlCron, policy, func() ([]*url.URL, error) {
+		var URLs []*url.URL
+		for _, seed := range seeds {
+			URL, err := url.Parse(seed)
+			if err != nil {
+				return nil, err
+			}
+
+			URLs = append(URLs, URL)
+		}
+
+		return URLs, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return recrawlScheduler.Start()
+}
+
+// stopRecrawlScheduler stops the recrawl scheduler, if started.
+func stopRecrawlScheduler() {
+	if recrawlScheduler != nil {
+		recrawlScheduler.Stop()
+	}
+}
@@ -0,0 +1,71 @@
+package controler
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/internal/pkg/source/lq"
+	"github.com/internetarchive/Zeno/internal/pkg/stats"
+)
+
+// shutdownReportFile is the name of the shutdown report, written in the job directory
+const shutdownReportFile = "shutdown_report.json"
+
+// ShutdownReport describes how a Zeno session ended.
+type ShutdownReport struct {
+	ExitReason       string           `json:"exit_reason"`
+	StoppedAt        time.Time        `json:"stopped_at"`
+	ShutdownDuration time.Duration    `json:"shutdown_duration"`
+	FinalStats       stats.CrawlStats `json:"final_stats"`
+	Errors           []string         `json:"errors,omitempty"`
+}
+
+// crawlStats returns a snapshot of the crawl stats, with the number of URLs left in the
+// local queue. HQ doesn't expose its queue size, QueuedURLs is 0 when using it.
+// The snapshot is returned even if the queue couldn't be counted.
+func crawlStats() (stats.CrawlStats, error) {
+	snapshot := stats.Snapshot()
+
+	if !config.Get().UseHQ {
+		queued, err := lq.RemainingURLs()
+		if err != nil {
+			return snapshot, err
+		}
+		snapshot.QueuedURLs = uint64(queued)
+	}
+
+	return snapshot, nil
+}
+
+// writeShutdownReport writes the report in the job directory, replacing the one of the previous session.
+func writeShutdownReport(jobDir string, report *ShutdownReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := filepath.Join(jobDir, shutdownReportFile+".tmp")
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, filepath.Join(jobDir, shutdownReportFile))
+}
+
+// ReadShutdownReport reads the shutdown report of the last session that ran in the job directory.
+func ReadShutdownReport(jobDir string) (*ShutdownReport, error) {
+	data, err := os.ReadFile(filepath.Join(jobDir, shutdownReportFile))
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(ShutdownReport)
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,40 @@
+package controler
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/stats"
+)
+
+func TestShutdownReport(t *testing.T) {
+	jobDir := t.TempDir()
+
+	if _, err := ReadShutdownReport(jobDir); !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error without report, got %v", err)
+	}
+
+	report := &ShutdownReport{
+		ExitReason:       "received signal terminated",
+		StoppedAt:        time.Now().Truncate(time.Second),
+		ShutdownDuration: 3 * time.Second,
+		FinalStats:       stats.CrawlStats{URLsFetched: 42, UniqueHosts: 2},
+		Errors:           []string{"unable to remove temp dir: busy"},
+	}
+
+	if err := writeShutdownReport(jobDir, report); err != nil {
+		t.Fatalf("unable to write report: %s", err)
+	}
+
+	read, err := ReadShutdownReport(jobDir)
+	if err != nil {
+		t.Fatalf("unable to read report: %s", err)
+	}
+
+	if read.ExitReason != report.ExitReason || !read.StoppedAt.Equal(report.StoppedAt) ||
+		read.ShutdownDuration != report.ShutdownDuration || read.FinalStats != report.FinalStats ||
+		len(read.Errors) != 1 || read.Errors[0] != report.Errors[0] {
+		t.Errorf("expected %+v, got %+v", report, read)
+	}
+}
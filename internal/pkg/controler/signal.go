@@ -9,7 +9,38 @@ import (
 	"github.com/internetarchive/Zeno/internal/pkg/log"
 )
 
-var signalWatcherCtx, signalWatcherCancel = context.WithCancel(context.Background())
+var (
+	signalWatcherCtx, signalWatcherCancel = context.WithCancel(context.Background())
+
+	// shutdownCh is used by Zeno's own components to request a graceful shutdown
+	shutdownCh = make(chan string, 1)
+)
+
+// requestShutdown asks for a graceful stop of Zeno, as if a SIGTERM was received.
+func requestShutdown(reason string) {
+	select {
+	case shutdownCh <- reason:
+	default:
+	}
+}
+
+// startShutdownWatcher calls stop once a shutdown is requested. It doesn't rely on
+// WatchSignals, that isn't running when the TUI is used.
+func startShutdownWatcher(stop func(reason string)) {
+	go func() {
+		logger := log.NewFieldedLogger(&log.Fields{
+			"component": "controler.shutdownWatcher",
+		})
+
+		select {
+		case <-stopped:
+			return
+		case reason := <-shutdownCh:
+			logger.Info("shutdown requested, stopping services...", "reason", reason)
+			stop(reason)
+		}
+	}()
+}
 
 // WatchSignals listens for OS signals and handles them gracefully
 func WatchSignals() {
@@ -23,8 +54,11 @@ func WatchSignals() {
 	select {
 	case <-signalWatcherCtx.Done():
 		return
-	case <-signalChan:
-		logger.Info("received shutdown signal, stopping services...")
+	case <-stopped:
+		// The pipeline was stopped by a shutdown request
+		os.Exit(0)
+	case sig := <-signalChan:
+		logger.Info("received shutdown signal, stopping services...", "signal", sig.String())
 		// Catch a second signal to force exit
 		go func() {
 			<-signalChan
@@ -32,7 +66,7 @@ func WatchSignals() {
 			os.Exit(1)
 		}()
 
-		Stop()
+		StopWithReason("received signal " + sig.String())
 		os.Exit(0)
 	}
 }
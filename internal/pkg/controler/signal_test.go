@@ -0,0 +1,24 @@
+package controler
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRequestShutdownWithoutSignalWatcher covers the TUI mode, where WatchSignals
+// isn't running: a shutdown request must still stop the pipeline.
+func TestRequestShutdownWithoutSignalWatcher(t *testing.T) {
+	reasons := make(chan string, 1)
+	startShutdownWatcher(func(reason string) { reasons <- reason })
+
+	requestShutdown("max-total-urls reached")
+
+	select {
+	case reason := <-reasons:
+		if reason != "max-total-urls reached" {
+			t.Errorf("expected the max-total-urls reason, got %q", reason)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("pipeline not stopped after a shutdown request")
+	}
+}
@@ -0,0 +1,183 @@
+package controler
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+	"github.com/internetarchive/Zeno/internal/pkg/preprocessor"
+	"github.com/internetarchive/Zeno/internal/pkg/stats"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcServerError    = -32000
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+var (
+	socketListener net.Listener
+	socketWg       sync.WaitGroup
+)
+
+// startControlSocket serves JSON-RPC 2.0 requests on a UNIX domain socket, one JSON object
+// per request. The methods are "stats", "pause" (with an optional "reason" param), "resume"
+// and "add-host-headers" (with "host" and "headers" params).
+func startControlSocket(path string) error {
+	logger := log.NewFieldedLogger(&log.Fields{
+		"component": "controler.controlSocket",
+	})
+
+	// Remove the socket left by a previous run that didn't stop cleanly
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	socketListener = listener
+
+	socketWg.Add(1)
+	go func() {
+		defer socketWg.Done()
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					logger.Error("unable to accept connection", "err", err.Error())
+				}
+				return
+			}
+
+			go serveControlConn(conn)
+		}
+	}()
+
+	logger.Info("listening", "path", path)
+
+	return nil
+}
+
+// stopControlSocket stops accepting connections and removes the socket.
+func stopControlSocket() {
+	if socketListener == nil {
+		return
+	}
+
+	socketListener.Close()
+	socketWg.Wait()
+	socketListener = nil
+}
+
+func serveControlConn(conn net.Conn) {
+	defer conn.Close()
+
+	decoder := json.NewDecoder(conn)
+	encoder := json.NewEncoder(conn)
+
+	for {
+		var req rpcRequest
+		if err := decoder.Decode(&req); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				encoder.Encode(rpcResponse{JSONRPC: "2.0", Error: &rpcError{Code: rpcParseError, Message: err.Error()}, ID: json.RawMessage("null")})
+			}
+			return
+		}
+
+		resp := handleControlRequest(req)
+
+		// Requests without ID are notifications, they don't get a response
+		if req.ID == nil {
+			continue
+		}
+
+		if err := encoder.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+func handleControlRequest(req rpcRequest) (resp rpcResponse) {
+	resp = rpcResponse{JSONRPC: "2.0", ID: req.ID}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{Code: rpcInvalidRequest, Message: "invalid JSON-RPC 2.0 request"}
+		return resp
+	}
+
+	switch req.Method {
+	case "stats":
+		resp.Result = stats.GetMapTUI()
+	case "pause":
+		var params struct {
+			Reason string `json:"reason"`
+		}
+		if len(req.Params) > 0 {
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				resp.Error = &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+				return resp
+			}
+		}
+
+		if params.Reason == "" {
+			params.Reason = "Paused from the control socket"
+		}
+
+		Pause(params.Reason)
+		resp.Result = "paused"
+	case "resume":
+		if !Resume() {
+			resp.Error = &rpcError{Code: rpcServerError, Message: "unable to resume the pipeline"}
+			return resp
+		}
+		resp.Result = "resumed"
+	case "add-host-headers":
+		var params struct {
+			Host    string            `json:"host"`
+			Headers map[string]string `json:"headers"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			resp.Error = &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+			return resp
+		}
+
+		if err := preprocessor.AddHostHeaders(params.Host, params.Headers); err != nil {
+			resp.Error = &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+			return resp
+		}
+		resp.Result = "added"
+	default:
+		resp.Error = &rpcError{Code: rpcMethodNotFound, Message: "method not found: " + req.Method}
+	}
+
+	return resp
+}
@@ -0,0 +1,86 @@
+package controler
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/internetarchive/Zeno/internal/pkg/stats"
+)
+
+func TestControlSocket(t *testing.T) {
+	stats.Init()
+
+	path := filepath.Join(t.TempDir(), "zeno.sock")
+	if err := startControlSocket(path); err != nil {
+		t.Fatalf("unable to start control socket: %s", err)
+	}
+	defer stopControlSocket()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("unable to connect to control socket: %s", err)
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	call := func(request string) (resp struct {
+		JSONRPC string          `json:"jsonrpc"`
+		Result  json.RawMessage `json:"result"`
+		Error   *rpcError       `json:"error"`
+		ID      json.RawMessage `json:"id"`
+	}) {
+		if _, err := conn.Write([]byte(request + "\n")); err != nil {
+			t.Fatalf("unable to send request: %s", err)
+		}
+
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("unable to read response: %s", err)
+		}
+
+		if err := json.Unmarshal(line, &resp); err != nil {
+			t.Fatalf("invalid response %q: %s", line, err)
+		}
+
+		return resp
+	}
+
+	resp := call(`{"jsonrpc": "2.0", "method": "stats", "id": 1}`)
+	if resp.Error != nil || string(resp.ID) != "1" {
+		t.Fatalf("unexpected stats response: %+v", resp)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatalf("invalid stats result: %s", err)
+	}
+	if _, ok := result["Total URL crawled"]; !ok {
+		t.Errorf("stats result is missing the crawled URLs: %v", result)
+	}
+
+	// Notifications don't get a response, the next response is for the next request
+	conn.Write([]byte(`{"jsonrpc": "2.0", "method": "stats"}` + "\n"))
+
+	resp = call(`{"jsonrpc": "2.0", "method": "reboot", "id": "a"}`)
+	if resp.Error == nil || resp.Error.Code != rpcMethodNotFound || string(resp.ID) != `"a"` {
+		t.Errorf("expected a method not found error, got %+v", resp)
+	}
+
+	resp = call(`{"jsonrpc": "2.0", "method": "add-host-headers", "params": {"host": "example.com", "headers": {"X-Test": "1"}}, "id": 3}`)
+	if resp.Error != nil || string(resp.Result) != `"added"` {
+		t.Errorf("unexpected add-host-headers response: %+v", resp)
+	}
+
+	resp = call(`{"jsonrpc": "2.0", "method": "add-host-headers", "params": {"headers": {"X-Test": "1"}}, "id": 4}`)
+	if resp.Error == nil || resp.Error.Code != rpcInvalidParams {
+		t.Errorf("expected an invalid params error, got %+v", resp)
+	}
+
+	resp = call(`{"method": "stats", "id": 2}`)
+	if resp.Error == nil || resp.Error.Code != rpcInvalidRequest {
+		t.Errorf("expected an invalid request error, got %+v", resp)
+	}
+}
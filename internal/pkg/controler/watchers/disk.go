@@ -8,10 +8,11 @@ import (
 	"time"
 
 	"github.com/internetarchive/Zeno/internal/pkg/config"
-	"github.com/internetarchive/Zeno/internal/pkg/controler/pause"
 	"github.com/internetarchive/Zeno/internal/pkg/log"
 )
 
+const diskPauseMessage = "Not enough disk space!!!"
+
 var (
 	diskWatcherCtx, diskWatcherCancel = context.WithCancel(context.Background())
 	diskWatcherWg                     sync.WaitGroup
@@ -81,12 +82,15 @@ func WatchDiskSpace(path string, interval time.Duration) {
 			err := CheckDiskUsage(path)
 
 			if err != nil && !paused {
-				logger.Warn("Low disk space, pausing the pipeline", "err", err.Error())
-				pause.Pause("Not enough disk space!!!")
-				paused = true
+				// Another watcher may have already paused the pipeline, try again on the next tick
+				if pauseIfRunning(diskPauseMessage) {
+					logger.Warn("Low disk space, paused the pipeline", "err", err.Error())
+					paused = true
+				}
 			} else if err == nil && paused {
-				logger.Info("Disk space is sufficient, resuming the pipeline")
-				pause.Resume()
+				if resumeIfPausedWith(diskPauseMessage) {
+					logger.Info("Disk space is sufficient, resumed the pipeline")
+				}
 				paused = false
 				if returnASAP {
 					return
@@ -0,0 +1,75 @@
+package watchers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/internal/pkg/controler/pause"
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+	"github.com/internetarchive/Zeno/internal/pkg/stats"
+)
+
+const errorRatePauseMessage = "Error rate too high, backing off"
+
+var (
+	errorRateWatcherCtx, errorRateWatcherCancel = context.WithCancel(context.Background())
+	errorRateWatcherWg                          sync.WaitGroup
+)
+
+// StartWatchErrorRate pauses the pipeline for --error-rate-backoff when the fraction of failed fetches
+// over the last --error-rate-window fetches exceeds --error-rate-threshold.
+func StartWatchErrorRate(interval time.Duration) {
+	threshold := config.Get().ErrorRateThreshold
+	if threshold <= 0 {
+		return
+	}
+
+	errorRateWatcherWg.Add(1)
+	go func() {
+		defer errorRateWatcherWg.Done()
+
+		logger := log.NewFieldedLogger(&log.Fields{
+			"component": "controler.errorRateWatcher",
+		})
+		defer logger.Debug("closed")
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-errorRateWatcherCtx.Done():
+				return
+			case <-ticker.C:
+				rate, samples := stats.FetchErrorsRateGet()
+
+				// Wait for the window to be full to avoid pausing on the first few failures
+				if samples < config.Get().ErrorRateWindow || rate <= threshold {
+					continue
+				}
+
+				// Another watcher already paused the pipeline
+				if pause.IsPaused() {
+					continue
+				}
+
+				logger.Warn("error rate too high, pausing the pipeline", "error_rate", rate, "threshold", threshold, "window", samples, "backoff", config.Get().ErrorRateBackoff.String())
+				if !pauseWithBackoff(errorRateWatcherCtx, errorRatePauseMessage, config.Get().ErrorRateBackoff) {
+					continue
+				}
+				logger.Info("backoff done")
+
+				// Start over with a fresh window, so that the failures that triggered the backoff don't trigger another one
+				stats.FetchErrorsReset()
+			}
+		}
+	}()
+}
+
+// StopErrorRateWatcher stops the error rate watcher, resuming the pipeline if it paused it.
+func StopErrorRateWatcher() {
+	errorRateWatcherCancel()
+	errorRateWatcherWg.Wait()
+}
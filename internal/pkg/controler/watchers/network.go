@@ -0,0 +1,103 @@
+package watchers
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+)
+
+const networkPauseMessage = "Network interface is down"
+
+var (
+	networkWatcherCtx, networkWatcherCancel = context.WithCancel(context.Background())
+	networkWatcherWg                        sync.WaitGroup
+
+	// interfaceAddrs returns the addresses of the named network interface, it can be overridden for testing.
+	interfaceAddrs = func(name string) ([]net.Addr, error) {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return iface.Addrs()
+	}
+)
+
+// interfaceIsUp returns true if the named network interface exists and has at least one
+// non link-local IP address.
+func interfaceIsUp(name string) bool {
+	addrs, err := interfaceAddrs(name)
+	if err != nil {
+		return false
+	}
+
+	for _, addr := range addrs {
+		var ip net.IP
+		switch v := addr.(type) {
+		case *net.IPNet:
+			ip = v.IP
+		case *net.IPAddr:
+			ip = v.IP
+		}
+
+		if ip != nil && !ip.IsUnspecified() && !ip.IsLinkLocalUnicast() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StartWatchNetworkInterface pauses the pipeline when the named network interface loses
+// its IP address, and resumes it when the interface gets an address back.
+func StartWatchNetworkInterface(name string, interval time.Duration) {
+	networkWatcherWg.Add(1)
+	go func() {
+		defer networkWatcherWg.Done()
+
+		logger := log.NewFieldedLogger(&log.Fields{
+			"component": "controler.networkWatcher",
+			"interface": name,
+		})
+		defer logger.Debug("closed")
+
+		paused := false
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-networkWatcherCtx.Done():
+				// Don't leave the pipeline paused, it would prevent it from stopping
+				if paused {
+					resumeIfPausedWith(networkPauseMessage)
+				}
+				return
+			case <-ticker.C:
+				up := interfaceIsUp(name)
+
+				if !up && !paused {
+					// Another watcher may have already paused the pipeline
+					if pauseIfRunning(networkPauseMessage) {
+						logger.Warn("network interface lost its IP address, paused the pipeline")
+						paused = true
+					}
+				} else if up && paused {
+					if resumeIfPausedWith(networkPauseMessage) {
+						logger.Info("network interface is back, resumed the pipeline")
+					}
+					paused = false
+				}
+			}
+		}
+	}()
+}
+
+// StopNetworkWatcher stops the network interface watcher.
+func StopNetworkWatcher() {
+	networkWatcherCancel()
+	networkWatcherWg.Wait()
+}
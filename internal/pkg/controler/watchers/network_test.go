@@ -0,0 +1,54 @@
+package watchers
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestInterfaceIsUp(t *testing.T) {
+	defer func(original func(string) ([]net.Addr, error)) { interfaceAddrs = original }(interfaceAddrs)
+
+	tests := []struct {
+		name  string
+		addrs []net.Addr
+		err   error
+		want  bool
+	}{
+		{
+			name: "interface not found",
+			err:  errors.New("no such network interface"),
+			want: false,
+		},
+		{
+			name:  "no address",
+			addrs: []net.Addr{},
+			want:  false,
+		},
+		{
+			name:  "only link-local address",
+			addrs: []net.Addr{&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)}},
+			want:  false,
+		},
+		{
+			name:  "IPv4 address",
+			addrs: []net.Addr{&net.IPNet{IP: net.ParseIP("192.168.1.10"), Mask: net.CIDRMask(24, 32)}},
+			want:  true,
+		},
+		{
+			name:  "IPv6 address",
+			addrs: []net.Addr{&net.IPAddr{IP: net.ParseIP("2001:db8::1")}},
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interfaceAddrs = func(string) ([]net.Addr, error) { return tt.addrs, tt.err }
+
+			if got := interfaceIsUp("wlan0"); got != tt.want {
+				t.Errorf("interfaceIsUp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
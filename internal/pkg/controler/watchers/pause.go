@@ -0,0 +1,50 @@
+package watchers
+
+import (
+	"context"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/controler/pause"
+)
+
+// The watchers pause the pipeline with their own message, and only resume it if it's still
+// paused with that message: the pipeline may have been resumed in the meantime (e.g. with
+// controler.Resume), or paused again by another watcher that must be left in charge.
+
+// pauseIfRunning pauses the pipeline with message, unless it's already paused.
+// It returns true if the pipeline was paused.
+func pauseIfRunning(message string) bool {
+	if pause.IsPaused() {
+		return false
+	}
+
+	pause.Pause(message)
+	return true
+}
+
+// resumeIfPausedWith resumes the pipeline if it's still paused with message.
+// It returns true if the pipeline was resumed.
+func resumeIfPausedWith(message string) bool {
+	if !pause.IsPaused() || pause.GetMessage() != message {
+		return false
+	}
+
+	pause.Resume()
+	return true
+}
+
+// pauseWithBackoff pauses the pipeline with message for backoff, or until ctx is done.
+// It returns false without waiting if the pipeline was already paused.
+func pauseWithBackoff(ctx context.Context, message string, backoff time.Duration) bool {
+	if !pauseIfRunning(message) {
+		return false
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(backoff):
+	}
+
+	resumeIfPausedWith(message)
+	return true
+}
@@ -0,0 +1,34 @@
+package watchers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/controler/pause"
+	"github.com/internetarchive/Zeno/internal/pkg/stats"
+)
+
+func TestPauseWithBackoff(t *testing.T) {
+	stats.Init()
+
+	if !pauseWithBackoff(context.Background(), "test backoff", 10*time.Millisecond) {
+		t.Fatal("expected the pipeline to be paused")
+	}
+
+	if pause.IsPaused() {
+		t.Fatal("expected the pipeline to be resumed after the backoff")
+	}
+
+	// The pipeline is already paused by someone else: it's left alone
+	pause.Pause("operator")
+	defer pause.Resume()
+
+	if pauseWithBackoff(context.Background(), "test backoff", 10*time.Millisecond) {
+		t.Error("expected the pipeline not to be paused twice")
+	}
+
+	if resumeIfPausedWith("test backoff") || !pause.IsPaused() {
+		t.Error("expected the pipeline paused by someone else not to be resumed")
+	}
+}
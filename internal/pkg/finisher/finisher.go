@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"sync/atomic"
 
 	"github.com/internetarchive/Zeno/internal/pkg/config"
 	"github.com/internetarchive/Zeno/internal/pkg/controler/pause"
@@ -27,8 +28,18 @@ var (
 	globalFinisher *finisher
 	once           sync.Once
 	logger         *log.FieldedLogger
+
+	// limitLogged ensures that reaching --max-total-urls is only logged once
+	limitLogged atomic.Bool
 )
 
+// URLLimitReached returns true if --max-total-urls is set and the number of
+// enqueued URLs reached it.
+func URLLimitReached() bool {
+	limit := config.Get().MaxTotalURLs
+	return limit > 0 && stats.URLsEnqueuedGet() >= limit
+}
+
 // Start initializes the global finisher with the given input channel.
 // This method can only be called once.
 func Start(inputChan, sourceFinishedChan, sourceProducedChan chan *models.Item) error {
@@ -114,8 +125,19 @@ func (f *finisher) worker(workerID string) {
 
 				// If the seed is fresh, send it to the source
 				if seed.GetStatus() == models.ItemFresh {
+					// If the URL limit is reached, discard the seed instead of enqueuing it
+					if URLLimitReached() {
+						if limitLogged.CompareAndSwap(false, true) {
+							logger.Info("maximum number of enqueued URLs reached, discarding newly discovered URLs", "limit", config.Get().MaxTotalURLs)
+						}
+						logger.Debug("discarding fresh seed due to URL limit", "seed", seed.GetShortID())
+						continue
+					}
+
 					logger.Debug("fresh seed received", "seed", seed)
 					f.sourceProducedCh <- seed
+					stats.URLsEnqueuedIncr()
+					stats.HostProgressQueuedIncr(seed.GetURL().GetParsed().Host)
 					continue
 				}
 
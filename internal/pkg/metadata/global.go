@@ -0,0 +1,75 @@
+package metadata
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+)
+
+var (
+	// ErrStoreAlreadyInitialized is returned when the global metadata store is already started
+	ErrStoreAlreadyInitialized = errors.New("metadata store already initialized")
+
+	globalStore *URLMetadataStore
+	logger      *log.FieldedLogger
+	globalMu    sync.RWMutex
+)
+
+// Start opens the global metadata store of the job at jobPath.
+func Start(jobPath string) error {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+
+	if globalStore != nil {
+		return ErrStoreAlreadyInitialized
+	}
+
+	logger = log.NewFieldedLogger(&log.Fields{
+		"component": "metadata",
+	})
+
+	store, err := Open(jobPath)
+	if err != nil {
+		return err
+	}
+
+	globalStore = store
+	logger.Info("started", "path", jobPath)
+
+	return nil
+}
+
+// Annotate sets the annotations on the normalized URL in the global metadata store,
+// it's a no-op if it isn't started.
+func Annotate(normalizedURL string, annotations map[string]string) {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+
+	if globalStore == nil {
+		return
+	}
+
+	for key, value := range annotations {
+		if err := globalStore.Set(normalizedURL, key, value); err != nil {
+			logger.Error("unable to annotate URL", "err", err.Error(), "url", normalizedURL, "key", key)
+		}
+	}
+}
+
+// Stop closes the global metadata store.
+func Stop() {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+
+	if globalStore == nil {
+		return
+	}
+
+	if err := globalStore.Close(); err != nil {
+		logger.Error("unable to close metadata store", "err", err.Error())
+	}
+
+	globalStore = nil
+	logger.Info("stopped")
+}
@@ -0,0 +1,134 @@
+// Package metadata persists arbitrary key-value annotations attached to crawled URLs
+// (e.g. "collection: news"), so that they can be retrieved once the crawl is done.
+package metadata
+
+import (
+	"database/sql"
+	"errors"
+	"iter"
+	"path"
+
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+)
+
+// FileName is the name of the metadata database in the job directory
+const FileName = "url_metadata.db"
+
+const schema = `CREATE TABLE IF NOT EXISTS url_metadata (
+	url TEXT NOT NULL,
+	key TEXT NOT NULL,
+	value TEXT NOT NULL,
+	PRIMARY KEY (url, key)
+);
+CREATE INDEX IF NOT EXISTS url_metadata_key ON url_metadata (key);`
+
+const (
+	upsertEntry   = `INSERT INTO url_metadata (url, key, value) VALUES (?, ?, ?) ON CONFLICT (url, key) DO UPDATE SET value = excluded.value`
+	selectEntry   = `SELECT value FROM url_metadata WHERE url = ? AND key = ?`
+	selectEntries = `SELECT url, value FROM url_metadata WHERE key = ? ORDER BY url`
+)
+
+// ErrEmptyKey is returned when setting an annotation without URL or key
+var ErrEmptyKey = errors.New("metadata URL and key must not be empty")
+
+// Entry is the value of a key for a given URL.
+type Entry struct {
+	URL   string
+	Value string
+}
+
+// URLMetadataStore stores the annotations of normalized URLs in a SQLite database.
+type URLMetadataStore struct {
+	db *sql.DB
+}
+
+// Open opens (creating it if needed) the metadata store of the job at jobPath.
+func Open(jobPath string) (*URLMetadataStore, error) {
+	return NewURLMetadataStore(path.Join(jobPath, FileName))
+}
+
+// NewURLMetadataStore opens (creating it if needed) the metadata store at dbPath.
+func NewURLMetadataStore(dbPath string) (*URLMetadataStore, error) {
+	db, err := sql.Open("sqlite3", "file:"+dbPath)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &URLMetadataStore{db: db}, nil
+}
+
+// Set sets the value of key for normalizedURL, replacing the previous one if any.
+func (s *URLMetadataStore) Set(normalizedURL, key, value string) error {
+	if normalizedURL == "" || key == "" {
+		return ErrEmptyKey
+	}
+
+	_, err := s.db.Exec(upsertEntry, normalizedURL, key, value)
+	return err
+}
+
+// Get returns the value of key for normalizedURL, and whether it was set.
+func (s *URLMetadataStore) Get(normalizedURL, key string) (string, bool, error) {
+	var value string
+
+	err := s.db.QueryRow(selectEntry, normalizedURL, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	return value, true, nil
+}
+
+// Entries streams, ordered by URL, all the URLs having key set along with its value.
+// Iteration stops after the first error, which is yielded with an empty entry.
+// The entries are read before being yielded, so the store can be updated while iterating.
+func (s *URLMetadataStore) Entries(key string) iter.Seq2[Entry, error] {
+	return func(yield func(Entry, error) bool) {
+		entries, err := s.readEntries(key)
+		if err != nil {
+			yield(Entry{}, err)
+			return
+		}
+
+		for _, entry := range entries {
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+// readEntries reads all the entries of key, releasing the only connection before returning.
+func (s *URLMetadataStore) readEntries(key string) ([]Entry, error) {
+	rows, err := s.db.Query(selectEntries, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var entry Entry
+		if err := rows.Scan(&entry.URL, &entry.Value); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// Close closes the underlying database.
+func (s *URLMetadataStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,146 @@
+package metadata
+
+import (
+	"testing"
+	"time"
+)
+
+func TestURLMetadataStore(t *testing.T) {
+	jobPath := t.TempDir()
+
+	store, err := Open(jobPath)
+	if err != nil {
+		t.Fatalf("unable to open store: %s", err)
+	}
+
+	for _, entry := range []struct{ url, key, value string }{
+		{"https://example.com/b", "collection", "news"},
+		{"https://example.com/a", "collection", "blogs"},
+		{"https://example.com/a", "priority", "high"},
+		{"https://example.com/a", "collection", "news"},
+	} {
+		if err := store.Set(entry.url, entry.key, entry.value); err != nil {
+			t.Fatalf("unable to set %s on %s: %s", entry.key, entry.url, err)
+		}
+	}
+
+	if err := store.Set("", "collection", "news"); err != ErrEmptyKey {
+		t.Errorf("expected ErrEmptyKey, got %v", err)
+	}
+
+	value, found, err := store.Get("https://example.com/a", "priority")
+	if err != nil || !found || value != "high" {
+		t.Errorf("expected high, got %q (found: %v, err: %v)", value, found, err)
+	}
+
+	_, found, err = store.Get("https://example.com/b", "priority")
+	if err != nil || found {
+		t.Errorf("expected no priority for /b, got found: %v, err: %v", found, err)
+	}
+
+	// The store is persisted in the job directory
+	if err := store.Close(); err != nil {
+		t.Fatalf("unable to close store: %s", err)
+	}
+
+	store, err = Open(jobPath)
+	if err != nil {
+		t.Fatalf("unable to reopen store: %s", err)
+	}
+	defer store.Close()
+
+	var entries []Entry
+	for entry, err := range store.Entries("collection") {
+		if err != nil {
+			t.Fatalf("unable to iterate entries: %s", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	expected := []Entry{{"https://example.com/a", "news"}, {"https://example.com/b", "news"}}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+
+	for i := range expected {
+		if entries[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], entries[i])
+		}
+	}
+
+	// Breaking out of the iteration early must not leak the query
+	for range store.Entries("collection") {
+		break
+	}
+
+	if _, _, err := store.Get("https://example.com/a", "collection"); err != nil {
+		t.Errorf("unable to query after an early break: %s", err)
+	}
+}
+
+func TestURLMetadataStoreSetWhileIterating(t *testing.T) {
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to open store: %s", err)
+	}
+	defer store.Close()
+
+	for _, URL := range []string{"https://example.com/a", "https://example.com/b"} {
+		if err := store.Set(URL, "collection", "news"); err != nil {
+			t.Fatalf("unable to set collection on %s: %s", URL, err)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for entry, err := range store.Entries("collection") {
+			if err != nil {
+				t.Errorf("unable to iterate entries: %s", err)
+				return
+			}
+
+			if err := store.Set(entry.URL, "reviewed", "yes"); err != nil {
+				t.Errorf("unable to set reviewed on %s: %s", entry.URL, err)
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Set while iterating the entries deadlocked")
+	}
+
+	if _, found, err := store.Get("https://example.com/b", "reviewed"); err != nil || !found {
+		t.Errorf("expected /b to be reviewed, got found: %v, err: %v", found, err)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	jobPath := t.TempDir()
+
+	// Annotating without a started store is a no-op
+	Annotate("https://example.com/a", map[string]string{"collection": "news"})
+
+	if err := Start(jobPath); err != nil {
+		t.Fatalf("unable to start store: %s", err)
+	}
+
+	Annotate("https://example.com/a", map[string]string{"collection": "news", "priority": "high"})
+	Stop()
+
+	store, err := Open(jobPath)
+	if err != nil {
+		t.Fatalf("unable to reopen store: %s", err)
+	}
+	defer store.Close()
+
+	for key, expected := range map[string]string{"collection": "news", "priority": "high"} {
+		value, found, err := store.Get("https://example.com/a", key)
+		if err != nil || !found || value != expected {
+			t.Errorf("expected %s to be %q, got %q (found: %v, err: %v)", key, expected, value, found, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package postprocessor
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/internetarchive/Zeno/internal/pkg/stats"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// recordDomainGraphEdges adds an edge to the domain graph for every outlink pointing
+// to another host than the one of the page it was found on.
+func recordDomainGraphEdges(pageURL *models.URL, outlinks []*models.URL) {
+	source := strings.ToLower(pageURL.GetParsed().Hostname())
+	if source == "" {
+		return
+	}
+
+	for _, outlink := range outlinks {
+		if outlink == nil {
+			continue
+		}
+
+		parsed, err := url.Parse(outlink.Raw)
+		if err != nil {
+			continue
+		}
+
+		target := strings.ToLower(parsed.Hostname())
+		if target == "" || target == source {
+			continue
+		}
+
+		stats.DomainGraphAddEdge(source, target)
+	}
+}
@@ -32,6 +32,9 @@ func HTMLOutlinks(item *models.Item) (outlinks []*models.URL, err error) {
 
 	var rawOutlinks []string
 
+	// Text of the <a> tags, by link, used to rank the outlinks
+	anchorTexts := make(map[string]string)
+
 	// Retrieve (potentially creates it) the document from the body
 	document, err := item.GetURL().GetDocument()
 	if err != nil {
@@ -73,6 +76,28 @@ func HTMLOutlinks(item *models.Item) (outlinks []*models.URL, err error) {
 				}
 
 				rawOutlinks = append(rawOutlinks, val)
+				if _, ok := anchorTexts[val]; !ok {
+					anchorTexts[val] = strings.Join(strings.Fields(sel.Text()), " ")
+				}
+			}
+		})
+	}
+
+	// Extract the page links from JSON-LD structured data (schema.org url, sameAs, ...),
+	// the assets found in these blocks are already captured by HTMLAssets.
+	// Only absolute links are kept, as JSON-LD values like "@type" would otherwise be resolved as relative URLs
+	if !slices.Contains(config.Get().DisableHTMLTag, "script") {
+		document.Find(`script[type="application/ld+json"]`).Each(func(index int, sel *goquery.Selection) {
+			_, URLsFromJSONLD, err := GetURLsFromJSON(json.NewDecoder(strings.NewReader(sel.Text())))
+			if err != nil {
+				logger.Debug("unable to extract URLs from JSON-LD script tag", "error", err, "url", item.GetURL().String(), "item", item.GetShortID())
+				return
+			}
+
+			for _, rawURL := range URLsFromJSONLD {
+				if strings.HasPrefix(rawURL, "http://") || strings.HasPrefix(rawURL, "https://") {
+					rawOutlinks = append(rawOutlinks, rawURL)
+				}
 			}
 		})
 	}
@@ -83,7 +108,8 @@ func HTMLOutlinks(item *models.Item) (outlinks []*models.URL, err error) {
 			logger.Debug("unable to resolve URL", "error", err, "url", item.GetURL().String(), "item", item.GetShortID())
 		} else if resolvedURL != "" {
 			outlinks = append(outlinks, &models.URL{
-				Raw: resolvedURL,
+				Raw:        resolvedURL,
+				AnchorText: anchorTexts[rawOutlink],
 			})
 			continue
 		}
@@ -95,7 +121,8 @@ func HTMLOutlinks(item *models.Item) (outlinks []*models.URL, err error) {
 		}
 
 		outlinks = append(outlinks, &models.URL{
-			Raw: rawOutlink,
+			Raw:        rawOutlink,
+			AnchorText: anchorTexts[rawOutlink],
 		})
 	}
 
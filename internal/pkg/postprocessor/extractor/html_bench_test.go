@@ -0,0 +1,75 @@
+package extractor
+
+import (
+	"bytes"
+	_ "embed"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/internetarchive/Zeno/internal/pkg/archiver"
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// Real-world shaped pages: a blog post, a news article and an aggregation page listing
+// thousands of stories
+var (
+	//go:embed testdata/blog_post.html
+	blogPostHTML string
+
+	//go:embed testdata/news_article.html
+	newsArticleHTML string
+
+	//go:embed testdata/aggregation.html
+	aggregationHTML string
+)
+
+func benchmarkExtractLinks(b *testing.B, body string) {
+	config.InitConfig()
+
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var links int
+	for i := 0; i < b.N; i++ {
+		// The parsed document is cached on the URL, so each iteration needs a fresh one
+		b.StopTimer()
+		resp := &http.Response{
+			Header: http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+			Body:   io.NopCloser(bytes.NewBufferString(body)),
+		}
+		newURL := &models.URL{Raw: "https://www.example.com/index.html"}
+		if err := newURL.Parse(); err != nil {
+			b.Fatalf("unable to parse URL: %s", err)
+		}
+		newURL.SetResponse(resp)
+		if err := archiver.ProcessBody(newURL, false, false, 0, os.TempDir()); err != nil {
+			b.Fatalf("ProcessBody() error = %v", err)
+		}
+		item := models.NewItem("benchmark", newURL, "")
+		b.StartTimer()
+
+		outlinks, err := HTMLOutlinks(item)
+		if err != nil {
+			b.Fatalf("error extracting HTML outlinks: %s", err)
+		}
+		links += len(outlinks)
+	}
+
+	b.ReportMetric(float64(links)/float64(b.N), "links/op")
+}
+
+func BenchmarkExtractLinksSmall(b *testing.B) {
+	benchmarkExtractLinks(b, blogPostHTML)
+}
+
+func BenchmarkExtractLinksMedium(b *testing.B) {
+	benchmarkExtractLinks(b, newsArticleHTML)
+}
+
+func BenchmarkExtractLinksLarge(b *testing.B) {
+	benchmarkExtractLinks(b, aggregationHTML)
+}
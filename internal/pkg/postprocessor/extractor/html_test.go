@@ -116,3 +116,145 @@ func TestHTMLAssetsAttributes(t *testing.T) {
 		t.Errorf("We couldn't extract all [data-item], [style], [data-preview] attribute assets. %d", len(assets))
 	}
 }
+
+func TestHTMLOutlinksJSONLD(t *testing.T) {
+	config.InitConfig()
+	body := `
+	<html>
+		<head>
+			<script type="application/ld+json">
+			{
+				"@context": "https://schema.org",
+				"@type": "NewsArticle",
+				"url": "https://example.com/news/article",
+				"image": "https://example.com/image.jpg",
+				"publisher": {"@type": "Organization", "sameAs": ["https://twitter.com/example"]}
+			}
+			</script>
+		</head>
+		<body><p>test</p></body>
+	</html>
+	`
+
+	resp := &http.Response{
+		Body: io.NopCloser(bytes.NewBufferString(body)),
+	}
+	newURL := &models.URL{Raw: "http://ex.com"}
+	newURL.SetResponse(resp)
+	err := archiver.ProcessBody(newURL, false, false, 0, os.TempDir())
+	if err != nil {
+		t.Errorf("ProcessBody() error = %v", err)
+	}
+	item := models.NewItem("test", newURL, "")
+
+	outlinks, err := HTMLOutlinks(item)
+	if err != nil {
+		t.Errorf("Error extracting HTML outlinks %s", err)
+	}
+
+	found := make(map[string]bool)
+	for _, outlink := range outlinks {
+		found[outlink.Raw] = true
+	}
+
+	for _, expected := range []string{"https://example.com/news/article", "https://twitter.com/example"} {
+		if !found[expected] {
+			t.Errorf("expected JSON-LD outlink %s, got %v", expected, outlinks)
+		}
+	}
+
+	if found["https://example.com/image.jpg"] {
+		t.Errorf("JSON-LD image shouldn't be an outlink")
+	}
+
+	if len(outlinks) != 2 {
+		t.Errorf("expected 2 JSON-LD outlinks, got %v", outlinks)
+	}
+}
+
+func TestHTMLOutlinksCharset(t *testing.T) {
+	config.InitConfig()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "charset in meta tag",
+			contentType: "text/html",
+			body:        "<html><head><meta charset=\"iso-8859-1\"></head><body><a href=\"http://example.com/caf\xe9\">caf\xe9</a></body></html>",
+		},
+		{
+			name:        "charset in Content-Type header",
+			contentType: "text/html; charset=windows-1252",
+			body:        "<html><head></head><body><a href=\"http://example.com/caf\xe9\">caf\xe9</a></body></html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header: http.Header{"Content-Type": []string{tt.contentType}},
+				Body:   io.NopCloser(bytes.NewBufferString(tt.body)),
+			}
+			newURL := &models.URL{Raw: "http://ex.com"}
+			newURL.SetResponse(resp)
+			err := archiver.ProcessBody(newURL, false, false, 0, os.TempDir())
+			if err != nil {
+				t.Fatalf("ProcessBody() error = %v", err)
+			}
+			item := models.NewItem("test", newURL, "")
+
+			outlinks, err := HTMLOutlinks(item)
+			if err != nil {
+				t.Fatalf("Error extracting HTML outlinks %s", err)
+			}
+			if len(outlinks) != 1 {
+				t.Fatalf("expected 1 outlink, got %d", len(outlinks))
+			}
+			if outlinks[0].Raw != "http://example.com/caf%C3%A9" {
+				t.Errorf("expected outlink to be transcoded to UTF-8, got %q", outlinks[0].Raw)
+			}
+		})
+	}
+}
+
+func TestHTMLOutlinksAnchorText(t *testing.T) {
+	config.InitConfig()
+	body := `<html><body>
+		<a href="/report">  Annual
+			report </a>
+		<a href="https://example.org/"><img src="logo.png"></a>
+	</body></html>`
+
+	resp := &http.Response{
+		Body: io.NopCloser(bytes.NewBufferString(body)),
+	}
+	newURL := &models.URL{Raw: "https://example.com/"}
+	if err := newURL.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	newURL.SetResponse(resp)
+	if err := archiver.ProcessBody(newURL, false, false, 0, os.TempDir()); err != nil {
+		t.Fatalf("ProcessBody() error = %v", err)
+	}
+
+	outlinks, err := HTMLOutlinks(models.NewItem("test", newURL, ""))
+	if err != nil {
+		t.Fatalf("Error extracting HTML outlinks %s", err)
+	}
+
+	anchorTexts := make(map[string]string)
+	for _, outlink := range outlinks {
+		anchorTexts[outlink.Raw] = outlink.AnchorText
+	}
+
+	if anchorTexts["https://example.com/report"] != "Annual report" {
+		t.Errorf("expected the normalized anchor text, got %q", anchorTexts["https://example.com/report"])
+	}
+
+	if text, ok := anchorTexts["https://example.org/"]; !ok || text != "" {
+		t.Errorf("expected an empty anchor text for the image link, got %q (found: %v)", text, ok)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/ImVexed/fasturl"
+	"github.com/internetarchive/Zeno/internal/pkg/config"
 	"github.com/internetarchive/Zeno/pkg/models"
 )
 
@@ -31,16 +32,27 @@ func JSON(URL *models.URL) (assets, outlinks []*models.URL, err error) {
 	return assets, outlinks, nil
 }
 
+// defaultJSONMaxDepth is the nesting depth after which JSON values are skipped, unless --json-max-depth is set
+const defaultJSONMaxDepth = 10
+
 func GetURLsFromJSON(decoder *json.Decoder) (assets, outlinks []string, err error) {
-	var data interface{}
+	maxDepth := defaultJSONMaxDepth
+	if config.Get() != nil && config.Get().JSONMaxDepth > 0 {
+		maxDepth = config.Get().JSONMaxDepth
+	}
 
-	err = decoder.Decode(&data)
+	links := make([]string, 0)
+
+	// Read the first token separately so that empty documents are reported as errors
+	token, err := decoder.Token()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	links := make([]string, 0)
-	findURLs(data, &links)
+	err = findURLs(decoder, token, 0, maxDepth, &links)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// We only consider as assets the URLs in which we can find a file extension
 	for _, link := range links {
@@ -64,28 +76,58 @@ func isLikelyJSON(str string) bool {
 	return ((str[0] == '{' && str[len(str)-1] == '}') || (str[0] == '[' && str[len(str)-1] == ']')) && strings.Contains(str, `"`)
 }
 
-func findURLs(data interface{}, links *[]string) {
-	switch v := data.(type) {
+// findURLs walks the JSON value starting with the given token, streaming the rest of it from the decoder.
+// Strings found deeper than maxDepth are ignored, but their tokens are still consumed.
+func findURLs(decoder *json.Decoder, token json.Token, depth, maxDepth int, links *[]string) error {
+	switch v := token.(type) {
 	case string:
+		if depth > maxDepth {
+			return nil
+		}
+
 		if isValidURL(v) {
 			*links = append(*links, v)
 		} else if isLikelyJSON(v) {
-			// handle JSON in JSON
-			var jsonstringdata interface{}
-			err := json.Unmarshal([]byte(v), &jsonstringdata)
-			if err == nil {
-				findURLs(jsonstringdata, links)
+			// handle JSON in JSON, errors are ignored as the string may not be JSON after all
+			nestedDecoder := json.NewDecoder(strings.NewReader(v))
+			if nestedToken, err := nestedDecoder.Token(); err == nil {
+				nestedLinks := make([]string, 0)
+				if err := findURLs(nestedDecoder, nestedToken, depth, maxDepth, &nestedLinks); err == nil {
+					*links = append(*links, nestedLinks...)
+				}
 			}
 		}
-	case []interface{}:
-		for _, element := range v {
-			findURLs(element, links)
+	case json.Delim:
+		if v != '{' && v != '[' {
+			return nil
 		}
-	case map[string]interface{}:
-		for _, value := range v {
-			findURLs(value, links)
+
+		isObject := v == '{'
+		for decoder.More() {
+			// Object keys are never considered as URLs
+			if isObject {
+				if _, err := decoder.Token(); err != nil {
+					return err
+				}
+			}
+
+			next, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+
+			if err := findURLs(decoder, next, depth+1, maxDepth, links); err != nil {
+				return err
+			}
+		}
+
+		// Consume the closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return err
 		}
 	}
+
+	return nil
 }
 
 func isValidURL(str string) bool {
@@ -2,9 +2,11 @@ package extractor
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/internetarchive/Zeno/internal/pkg/archiver"
@@ -114,3 +116,21 @@ func TestJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestGetURLsFromJSONMaxDepth(t *testing.T) {
+	nest := func(levels int, link string) string {
+		return strings.Repeat(`{"a":`, levels) + `"` + link + `"` + strings.Repeat(`}`, levels)
+	}
+
+	body := `[` + nest(5, "https://shallow.example.com") + `,` + nest(15, "https://deep.example.com") + `]`
+
+	assets, outlinks, err := GetURLsFromJSON(json.NewDecoder(strings.NewReader(body)))
+	if err != nil {
+		t.Fatalf("GetURLsFromJSON() error = %v", err)
+	}
+
+	links := append(assets, outlinks...)
+	if len(links) != 1 || links[0] != "https://shallow.example.com" {
+		t.Errorf("expected only the shallow URL to be extracted, got %v", links)
+	}
+}
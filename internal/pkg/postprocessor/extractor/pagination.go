@@ -0,0 +1,75 @@
+package extractor
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// PaginationLinks returns the rel="next" links of a page, found either in its Link
+// header or in the <a> and <link> elements of its HTML document.
+func PaginationLinks(item *models.Item) (links []*models.URL) {
+	var rawLinks []string
+
+	// Link: <https://example.com/page/2>; rel="next"
+	for _, link := range strings.Split(item.GetURL().GetResponse().Header.Get("link"), ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+
+		for _, attr := range parts[1:] {
+			key, value := parseAttr(attr)
+			if key == "rel" && hasRelNext(value) {
+				rawLinks = append(rawLinks, strings.TrimSpace(strings.Trim(strings.TrimSpace(parts[0]), "<>")))
+				break
+			}
+		}
+	}
+
+	if IsHTML(item.GetURL()) && item.GetURL().GetBody() != nil {
+		defer item.GetURL().RewindBody()
+
+		document, err := item.GetURL().GetDocument()
+		if err == nil {
+			extractBaseTag(item, document)
+
+			document.Find("a[rel], link[rel]").Each(func(_ int, sel *goquery.Selection) {
+				rel, _ := sel.Attr("rel")
+				href, exists := sel.Attr("href")
+				if exists && href != "" && hasRelNext(rel) {
+					rawLinks = append(rawLinks, href)
+				}
+			})
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, rawLink := range rawLinks {
+		resolved, err := resolveURL(rawLink, item)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := seen[resolved]; ok {
+			continue
+		}
+		seen[resolved] = struct{}{}
+
+		links = append(links, &models.URL{Raw: resolved})
+	}
+
+	return links
+}
+
+// hasRelNext returns true if the space-separated rel value contains "next".
+func hasRelNext(rel string) bool {
+	for _, value := range strings.Fields(rel) {
+		if strings.EqualFold(value, "next") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package extractor
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/internetarchive/Zeno/internal/pkg/archiver"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func TestPaginationLinks(t *testing.T) {
+	resp := &http.Response{
+		Header: http.Header{
+			"Content-Type": []string{"text/html"},
+			"Link":         []string{`<https://example.com/api?page=2>; rel="next", <https://example.com/style.css>; rel="stylesheet"`},
+		},
+		Body: io.NopCloser(bytes.NewBufferString(`<html><head><link rel="next" href="/list?page=2"></head><body>
+			<a href="/about">About</a>
+			<a rel="nofollow next" href="/list?page=2">Next</a>
+			<a rel="prev" href="/list?page=0">Previous</a>
+		</body></html>`)),
+	}
+
+	newURL := &models.URL{Raw: "https://example.com/list?page=1"}
+	if err := newURL.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	newURL.SetResponse(resp)
+
+	if err := archiver.ProcessBody(newURL, false, false, 0, os.TempDir()); err != nil {
+		t.Fatalf("ProcessBody() error = %v", err)
+	}
+
+	links := PaginationLinks(models.NewItem("test", newURL, ""))
+
+	expected := []string{"https://example.com/api?page=2", "https://example.com/list?page=2"}
+	if len(links) != len(expected) {
+		t.Fatalf("expected %d links, got %d: %v", len(expected), len(links), links)
+	}
+
+	for i := range expected {
+		if links[i].Raw != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], links[i].Raw)
+		}
+	}
+}
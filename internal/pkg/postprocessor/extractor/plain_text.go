@@ -0,0 +1,48 @@
+package extractor
+
+import (
+	"bufio"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// plainTextMaxLineSize is the longest line considered by the plain text extractor
+const plainTextMaxLineSize = 1024 * 1024
+
+// IsPlainText returns true if the response is declared as text/plain.
+func IsPlainText(URL *models.URL) bool {
+	if URL.GetResponse() == nil {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(URL.GetResponse().Header.Get("Content-Type"))
+	return err == nil && mediaType == "text/plain"
+}
+
+// PlainText extracts the links of a one-URL-per-line document, like a seed list.
+// Blank lines, and lines that aren't a single absolute http(s) URL, are skipped.
+func PlainText(URL *models.URL) (outlinks []*models.URL, err error) {
+	defer URL.RewindBody()
+
+	scanner := bufio.NewScanner(URL.GetBody())
+	scanner.Buffer(make([]byte, 0, 64*1024), plainTextMaxLineSize)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.ContainsAny(line, " \t") {
+			continue
+		}
+
+		parsed, err := url.Parse(line)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			continue
+		}
+
+		outlinks = append(outlinks, &models.URL{Raw: line})
+	}
+
+	return outlinks, scanner.Err()
+}
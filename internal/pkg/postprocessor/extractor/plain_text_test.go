@@ -0,0 +1,70 @@
+package extractor
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/internetarchive/Zeno/internal/pkg/archiver"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func TestPlainText(t *testing.T) {
+	body := "https://example.com/\r\n" +
+		"  http://example.org/page?id=1  \n" +
+		"\n" +
+		"# not a link\n" +
+		"see https://example.net/ for more\n" +
+		"ftp://example.com/file\n" +
+		"/relative/path\n" +
+		"https://example.com/last"
+
+	resp := &http.Response{
+		Header: http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+		Body:   io.NopCloser(bytes.NewBufferString(body)),
+	}
+	newURL := &models.URL{Raw: "https://example.com/seeds.txt"}
+	newURL.SetResponse(resp)
+	if err := archiver.ProcessBody(newURL, false, false, 0, os.TempDir()); err != nil {
+		t.Fatalf("ProcessBody() error = %v", err)
+	}
+
+	if !IsPlainText(newURL) {
+		t.Fatalf("expected the response to be detected as text/plain")
+	}
+
+	outlinks, err := PlainText(newURL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"https://example.com/", "http://example.org/page?id=1", "https://example.com/last"}
+	if len(outlinks) != len(expected) {
+		t.Fatalf("expected %d outlinks, got %d", len(expected), len(outlinks))
+	}
+
+	for i := range expected {
+		if outlinks[i].Raw != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], outlinks[i].Raw)
+		}
+	}
+}
+
+func TestIsPlainText(t *testing.T) {
+	for contentType, expected := range map[string]bool{
+		"text/plain":               true,
+		"TEXT/PLAIN; charset=utf8": true,
+		"text/html":                false,
+		"text/plain-ish":           false,
+		"":                         false,
+	} {
+		URL := &models.URL{Raw: "https://example.com/"}
+		URL.SetResponse(&http.Response{Header: http.Header{"Content-Type": []string{contentType}}})
+
+		if IsPlainText(URL) != expected {
+			t.Errorf("%q: expected %v", contentType, expected)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package extractor
+
+import (
+	"io"
+	"strings"
+
+	"github.com/internetarchive/Zeno/pkg/models"
+	"golang.org/x/net/html"
+)
+
+// RobotsDirective is a bitmask of the directives found in the robots <meta> tags of a page.
+type RobotsDirective uint8
+
+const (
+	// RobotsNoindex means that the page shouldn't be indexed
+	RobotsNoindex RobotsDirective = 1 << iota
+	// RobotsNofollow means that the links of the page shouldn't be followed
+	RobotsNofollow
+	// RobotsNoarchive means that the page shouldn't be archived
+	RobotsNoarchive
+)
+
+// robotsMetaMaxBytes is the number of bytes of the body in which robots <meta> tags are searched
+const robotsMetaMaxBytes = 8192
+
+// Has returns true if all the given directives are set.
+func (d RobotsDirective) Has(directive RobotsDirective) bool {
+	return d&directive == directive
+}
+
+// RobotsMeta parses the first 8 KB of an HTML body and returns the directives of
+// its <meta name="robots"> tags. "none" is equivalent to "noindex, nofollow".
+func RobotsMeta(URL *models.URL) (directives RobotsDirective) {
+	if URL.GetBody() == nil {
+		return 0
+	}
+	defer URL.RewindBody()
+
+	tokenizer := html.NewTokenizer(io.LimitReader(URL.GetBody(), robotsMetaMaxBytes))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return directives
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.Data == "body" {
+				return directives
+			}
+
+			if token.Data != "meta" {
+				continue
+			}
+
+			var name, content string
+			for _, attr := range token.Attr {
+				switch strings.ToLower(attr.Key) {
+				case "name":
+					name = strings.ToLower(strings.TrimSpace(attr.Val))
+				case "content":
+					content = strings.ToLower(attr.Val)
+				}
+			}
+
+			if name != "robots" {
+				continue
+			}
+
+			for _, value := range strings.Split(content, ",") {
+				switch strings.TrimSpace(value) {
+				case "noindex":
+					directives |= RobotsNoindex
+				case "nofollow":
+					directives |= RobotsNofollow
+				case "noarchive":
+					directives |= RobotsNoarchive
+				case "none":
+					directives |= RobotsNoindex | RobotsNofollow
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package extractor
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/internetarchive/Zeno/internal/pkg/archiver"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func TestRobotsMeta(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want RobotsDirective
+	}{
+		{
+			name: "no robots meta",
+			body: `<html><head><meta name="description" content="nofollow"></head></html>`,
+			want: 0,
+		},
+		{
+			name: "nofollow and noarchive",
+			body: `<html><head><meta name="Robots" content="NoFollow, noarchive"></head></html>`,
+			want: RobotsNofollow | RobotsNoarchive,
+		},
+		{
+			name: "none",
+			body: `<html><head><meta name="robots" content="none"/></head></html>`,
+			want: RobotsNoindex | RobotsNofollow,
+		},
+		{
+			name: "meta in body is ignored",
+			body: `<html><head></head><body><meta name="robots" content="nofollow"></body></html>`,
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header: http.Header{"Content-Type": []string{"text/html"}},
+				Body:   io.NopCloser(bytes.NewBufferString(tt.body)),
+			}
+
+			URL := &models.URL{Raw: "https://example.com"}
+			URL.SetResponse(resp)
+
+			if err := archiver.ProcessBody(URL, false, false, 0, os.TempDir()); err != nil {
+				t.Fatalf("ProcessBody() error = %v", err)
+			}
+
+			if got := RobotsMeta(URL); got != tt.want {
+				t.Errorf("RobotsMeta() = %b, want %b", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/internetarchive/Zeno/internal/pkg/config"
 	"github.com/internetarchive/Zeno/internal/pkg/log"
+	"github.com/internetarchive/Zeno/internal/pkg/metadata"
 	"github.com/internetarchive/Zeno/internal/pkg/postprocessor/domainscrawl"
+	"github.com/internetarchive/Zeno/internal/pkg/postprocessor/extractor"
 	"github.com/internetarchive/Zeno/internal/pkg/postprocessor/sitespecific/reddit"
 	"github.com/internetarchive/Zeno/pkg/models"
 )
@@ -28,6 +30,9 @@ func postprocessItem(item *models.Item) []*models.Item {
 
 	logger.Debug("postprocessing item", "item_id", item.GetShortID())
 
+	// Attach the --url-metadata annotations to the archived URL
+	metadata.Annotate(item.GetURL().String(), config.Get().URLAnnotations)
+
 	// Verify if there is any redirection
 	if isStatusCodeRedirect(item.GetURL().GetResponse().StatusCode) {
 		logger.Debug("item is a redirection", "item_id", item.GetShortID())
@@ -93,8 +98,17 @@ func postprocessItem(item *models.Item) []*models.Item {
 
 		var outlinksFromAssets []*models.URL
 
-		// Extract assets from the page
-		if shouldExtractAssets(item) {
+		// If enabled, honor the directives of the robots <meta> tags
+		var robotsDirectives extractor.RobotsDirective
+		if config.Get().HonorMetaRobots && extractor.IsHTML(item.GetURL()) {
+			robotsDirectives = extractor.RobotsMeta(item.GetURL())
+			if robotsDirectives != 0 {
+				logger.Debug("found robots meta directives", "item_id", item.GetShortID(), "nofollow", robotsDirectives.Has(extractor.RobotsNofollow), "noarchive", robotsDirectives.Has(extractor.RobotsNoarchive))
+			}
+		}
+
+		// Extract assets from the page, unless the page asks not to be archived
+		if shouldExtractAssets(item) && !robotsDirectives.Has(extractor.RobotsNoarchive) {
 			var assets []*models.URL
 			var err error
 
@@ -134,9 +148,9 @@ func postprocessItem(item *models.Item) []*models.Item {
 			}
 		}
 
-		// Extract outlinks from the page
-		if shouldExtractOutlinks(item) {
-			newOutlinks, err := extractOutlinks(item)
+		// Extract outlinks from the page, unless the page asks for its links not to be followed
+		if shouldExtractOutlinks(item) && !robotsDirectives.Has(extractor.RobotsNofollow) {
+			newOutlinks, err := extractOutlinksCached(item)
 			if err != nil {
 				logger.Error("unable to extract outlinks", "err", err.Error(), "item_id", item.GetShortID())
 			} else {
@@ -164,9 +178,35 @@ func postprocessItem(item *models.Item) []*models.Item {
 					outlinks = append(outlinks, newOutlinkItem)
 				}
 
+				// Record the host-to-host links, followed or not, in the domain graph
+				if config.Get().DomainGraph != "" {
+					recordDomainGraphEdges(item.GetURL(), newOutlinks)
+				}
+
 				logger.Debug("extracted outlinks", "item_id", item.GetShortID(), "count", len(newOutlinks))
 			}
 		}
+
+		// Follow the pagination with the same hop count, even if --max-hops prevented outlinks extraction
+		if config.Get().FollowPagination && !robotsDirectives.Has(extractor.RobotsNofollow) {
+			paginationOutlinks := extractPaginationOutlinks(item)
+			outlinks = mergePaginationOutlinks(outlinks, paginationOutlinks)
+
+			logger.Debug("extracted pagination outlinks", "item_id", item.GetShortID(), "count", len(paginationOutlinks))
+		}
+
+		// Don't leave the registered domains (eTLD+1) of the seeds
+		if config.Get().StayOnRegisteredDomain {
+			recordSeedRegisteredDomain(item)
+
+			count := len(outlinks)
+			outlinks = filterRegisteredDomain(outlinks)
+
+			logger.Debug("filtered outlinks outside of the seeds registered domains", "item_id", item.GetShortID(), "count", count-len(outlinks))
+		}
+
+		// Hand the most promising outlinks to the source first, pagination included
+		sortOutlinksByScore(item.GetURL(), outlinks)
 	}
 
 	// Make sure the goquery document's memory can be freed
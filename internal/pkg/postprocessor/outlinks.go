@@ -71,6 +71,12 @@ func extractOutlinks(item *models.Item) (outlinks []*models.URL, err error) {
 			logger.Error("unable to extract outlinks", "extractor", "PDF", "err", err.Error(), "item", item.GetShortID(), "url", item.GetURL().String())
 			return outlinks, err
 		}
+	case extractor.IsPlainText(item.GetURL()):
+		outlinks, err = extractor.PlainText(item.GetURL())
+		if err != nil {
+			logger.Error("unable to extract outlinks", "extractor", "PlainText", "err", err.Error(), "item", item.GetShortID(), "url", item.GetURL().String())
+			return outlinks, err
+		}
 	case reddit.IsPostAPI(item.GetURL()):
 		outlinks, err = reddit.ExtractAPIPostPermalinks(item)
 		if err != nil {
@@ -82,14 +88,17 @@ func extractOutlinks(item *models.Item) (outlinks []*models.URL, err error) {
 		return outlinks, nil
 	}
 
+	// A text/plain list of URLs is already handled line by line, other text/* pages
+	// get their links extracted from the body (aggressively)
+	extractFromPage := strings.Contains(contentType, "text/") && !(extractor.IsPlainText(item.GetURL()) && len(outlinks) > 0)
+
 	// Try to extract links from link headers
 	linksFromLinkHeader := extractor.ExtractURLsFromHeader(item.GetURL())
 	if linksFromLinkHeader != nil {
 		outlinks = append(outlinks, linksFromLinkHeader...)
 	}
 
-	// If the page is a text/* content type, extract links from the body (aggressively)
-	if strings.Contains(contentType, "text/") {
+	if extractFromPage {
 		outlinks = append(outlinks, extractLinksFromPage(item.GetURL())...)
 	}
 
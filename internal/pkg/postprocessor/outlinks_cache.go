@@ -0,0 +1,138 @@
+package postprocessor
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"sync"
+
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// contentHashLinkCache is a bounded LRU cache mapping the hash of a body to the links extracted from it.
+type contentHashLinkCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front is the most recently used
+	entries map[string]*list.Element
+}
+
+type linkCacheEntry struct {
+	key   string
+	links []cachedLink
+}
+
+// cachedLink is an extracted link, with the anchor text used to rank it
+type cachedLink struct {
+	raw        string
+	anchorText string
+}
+
+var (
+	globalLinkCache     *contentHashLinkCache
+	globalLinkCacheOnce sync.Once
+)
+
+func newContentHashLinkCache(size int) *contentHashLinkCache {
+	return &contentHashLinkCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (c *contentHashLinkCache) get(key string) ([]cachedLink, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*linkCacheEntry).links, true
+}
+
+func (c *contentHashLinkCache) add(key string, links []cachedLink) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*linkCacheEntry).links = links
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&linkCacheEntry{key: key, links: links})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*linkCacheEntry).key)
+	}
+}
+
+// getLinkCache returns the global link cache, or nil if it's disabled.
+func getLinkCache() *contentHashLinkCache {
+	globalLinkCacheOnce.Do(func() {
+		if config.Get().OutlinksCacheSize > 0 {
+			globalLinkCache = newContentHashLinkCache(config.Get().OutlinksCacheSize)
+		}
+	})
+
+	return globalLinkCache
+}
+
+// linkCacheKey returns the key of the item in the link cache. Relative links are resolved
+// against the page URL, so the directory of the page and its content type are part of the key.
+func linkCacheKey(item *models.Item) (string, error) {
+	defer item.GetURL().RewindBody()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, item.GetURL().GetBody()); err != nil {
+		return "", err
+	}
+
+	base := item.GetURL().GetParsed().ResolveReference(&url.URL{Path: "."})
+
+	return hex.EncodeToString(hash.Sum(nil)) + "|" + item.GetURL().GetResponse().Header.Get("Content-Type") + "|" + base.String(), nil
+}
+
+// extractOutlinksCached wraps extractOutlinks with the link cache, so that the same body
+// served by multiple URLs (e.g. redirect aliases) is only parsed once.
+func extractOutlinksCached(item *models.Item) (outlinks []*models.URL, err error) {
+	cache := getLinkCache()
+	if cache == nil || item.GetURL().GetBody() == nil {
+		return extractOutlinks(item)
+	}
+
+	key, err := linkCacheKey(item)
+	if err != nil {
+		return extractOutlinks(item)
+	}
+
+	if links, ok := cache.get(key); ok {
+		for _, link := range links {
+			outlinks = append(outlinks, &models.URL{Raw: link.raw, AnchorText: link.anchorText, Hops: item.GetURL().GetHops() + 1})
+		}
+
+		return outlinks, nil
+	}
+
+	outlinks, err = extractOutlinks(item)
+	if err != nil {
+		return outlinks, err
+	}
+
+	links := make([]cachedLink, 0, len(outlinks))
+	for _, outlink := range outlinks {
+		links = append(links, cachedLink{raw: outlink.Raw, anchorText: outlink.AnchorText})
+	}
+	cache.add(key, links)
+
+	return outlinks, nil
+}
@@ -0,0 +1,27 @@
+package postprocessor
+
+import "testing"
+
+func TestContentHashLinkCache(t *testing.T) {
+	cache := newContentHashLinkCache(2)
+
+	cache.add("a", []cachedLink{{raw: "https://example.com/a"}})
+	cache.add("b", []cachedLink{{raw: "https://example.com/b"}})
+
+	// Use "a" so that "b" becomes the least recently used entry
+	if links, ok := cache.get("a"); !ok || links[0].raw != "https://example.com/a" {
+		t.Fatalf("expected a cache hit for a, got %v", links)
+	}
+
+	cache.add("c", []cachedLink{{raw: "https://example.com/c"}})
+
+	if _, ok := cache.get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.get(key); !ok {
+			t.Errorf("expected a cache hit for %s", key)
+		}
+	}
+}
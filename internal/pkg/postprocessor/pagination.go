@@ -0,0 +1,59 @@
+package postprocessor
+
+import (
+	"github.com/google/uuid"
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/internal/pkg/postprocessor/extractor"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// extractPaginationOutlinks returns the rel="next" pages of the item as new seeds with the same
+// hop count as the item, so that paginated resources aren't cut by --max-hops.
+// The chains are bounded by --max-pagination-depth, the position in the chain being carried by the URLs.
+func extractPaginationOutlinks(item *models.Item) (outlinks []*models.Item) {
+	// Only pages are paginated, not their assets
+	if item.GetDepthWithoutRedirections() != 0 {
+		return nil
+	}
+
+	// The page may be the target of redirections, the chain position is the one of the queued URL
+	depth := item.GetURL().PaginationDepth
+	if seed := item.GetSeed(); seed != nil {
+		depth = seed.GetURL().PaginationDepth
+	}
+
+	if config.Get().MaxPaginationDepth > 0 && depth >= config.Get().MaxPaginationDepth {
+		return nil
+	}
+
+	for _, link := range extractor.PaginationLinks(item) {
+		link.SetHops(item.GetURL().GetHops())
+		link.PaginationDepth = depth + 1
+
+		outlinks = append(outlinks, models.NewItem(uuid.New().String(), link, item.GetURL().String()))
+	}
+
+	return outlinks
+}
+
+// mergePaginationOutlinks adds the pagination outlinks to the outlinks, replacing any outlink
+// to the same URL as they carry a lower hop count.
+func mergePaginationOutlinks(outlinks, pagination []*models.Item) []*models.Item {
+	if len(pagination) == 0 {
+		return outlinks
+	}
+
+	paginated := make(map[string]struct{}, len(pagination))
+	for _, p := range pagination {
+		paginated[p.GetURL().Raw] = struct{}{}
+	}
+
+	merged := make([]*models.Item, 0, len(outlinks)+len(pagination))
+	for _, outlink := range outlinks {
+		if _, ok := paginated[outlink.GetURL().Raw]; !ok {
+			merged = append(merged, outlink)
+		}
+	}
+
+	return append(merged, pagination...)
+}
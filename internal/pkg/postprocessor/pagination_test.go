@@ -0,0 +1,59 @@
+package postprocessor
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func newPaginatedItem(t *testing.T, rawURL, next string, paginationDepth int) *models.Item {
+	t.Helper()
+
+	URL := &models.URL{Raw: rawURL, Hops: 1, PaginationDepth: paginationDepth}
+	if err := URL.Parse(); err != nil {
+		t.Fatalf("unable to parse %s: %s", rawURL, err)
+	}
+	URL.SetResponse(&http.Response{Header: http.Header{"Link": []string{"<" + next + `>; rel="next"`}}})
+	URL.SetMIMEType(mimetype.Lookup("application/json"))
+
+	return models.NewItem(rawURL, URL, "")
+}
+
+func TestExtractPaginationOutlinksDepth(t *testing.T) {
+	if err := config.InitConfig(); err != nil {
+		t.Fatal(err)
+	}
+	config.Get().MaxPaginationDepth = 3
+	defer func() { config.Get().MaxPaginationDepth = 0 }()
+
+	// The next page keeps the hop count and is one step further in the chain
+	outlinks := extractPaginationOutlinks(newPaginatedItem(t, "https://example.com/page/2", "https://example.com/page/3", 1))
+	if len(outlinks) != 1 {
+		t.Fatalf("expected 1 pagination outlink, got %d", len(outlinks))
+	}
+
+	if outlinks[0].GetURL().GetHops() != 1 || outlinks[0].GetURL().PaginationDepth != 2 {
+		t.Errorf("expected hops 1 and pagination depth 2, got %d and %d", outlinks[0].GetURL().GetHops(), outlinks[0].GetURL().PaginationDepth)
+	}
+
+	// The end of the chain isn't followed
+	if outlinks := extractPaginationOutlinks(newPaginatedItem(t, "https://example.com/page/4", "https://example.com/page/5", 3)); len(outlinks) != 0 {
+		t.Errorf("expected no pagination outlink at --max-pagination-depth, got %d", len(outlinks))
+	}
+
+	// A next page that redirects keeps its position in the chain
+	seed := newPaginatedItem(t, "https://example.com/page/4", "https://example.com/page/5", 3)
+	seed.SetStatus(models.ItemGotRedirected)
+
+	redirection := newPaginatedItem(t, "https://example.com/page/4/", "https://example.com/page/5", 0)
+	if err := seed.AddChild(redirection, models.ItemGotRedirected); err != nil {
+		t.Fatal(err)
+	}
+
+	if outlinks := extractPaginationOutlinks(redirection); len(outlinks) != 0 {
+		t.Errorf("expected no pagination outlink after a redirection at --max-pagination-depth, got %d", len(outlinks))
+	}
+}
@@ -39,7 +39,15 @@ func Start(inputChan, outputChan chan *models.Item) error {
 
 	stats.Init()
 
+	var startErr error
 	once.Do(func() {
+		if config.Get().StayOnRegisteredDomain {
+			if startErr = openSeedRegisteredDomains(config.Get().JobPath); startErr != nil {
+				once = sync.Once{}
+				return
+			}
+		}
+
 		ctx, cancel := context.WithCancel(context.Background())
 		globalPostprocessor = &postprocessor{
 			ctx:      ctx,
@@ -56,6 +64,10 @@ func Start(inputChan, outputChan chan *models.Item) error {
 		done = true
 	})
 
+	if startErr != nil {
+		return fmt.Errorf("unable to open the seed registered domains: %w", startErr)
+	}
+
 	if !done {
 		return ErrPostprocessorAlreadyInitialized
 	}
@@ -67,6 +79,9 @@ func Stop() {
 	if globalPostprocessor != nil {
 		globalPostprocessor.cancel()
 		globalPostprocessor.wg.Wait()
+		if err := closeSeedRegisteredDomains(); err != nil {
+			logger.Error("unable to close the seed registered domains", "err", err.Error())
+		}
 		logger.Info("stopped")
 	}
 }
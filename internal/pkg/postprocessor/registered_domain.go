@@ -0,0 +1,133 @@
+package postprocessor
+
+import (
+	"bufio"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/internetarchive/Zeno/pkg/models"
+	"golang.org/x/net/publicsuffix"
+)
+
+// seedRegisteredDomainsFile is the file of the job directory where the registered domains
+// of the seeds are kept, so that they survive restarts.
+const seedRegisteredDomainsFile = "seed_registered_domains.txt"
+
+var (
+	// seedRegisteredDomains holds the registered domains (eTLD+1) of the seeds processed so far,
+	// used by --stay-on-registered-domain.
+	seedRegisteredDomains sync.Map // map[string]struct{}
+
+	// seedRegisteredDomainsLog is the file the new seed registered domains are appended to.
+	seedRegisteredDomainsLog struct {
+		sync.Mutex
+		file *os.File
+	}
+)
+
+// registeredDomain returns the eTLD+1 of the URL (e.g. example.co.uk for www.example.co.uk),
+// or its hostname if it has none (IP addresses, public suffixes).
+func registeredDomain(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	hostname := strings.ToLower(parsed.Hostname())
+
+	domain, err := publicsuffix.EffectiveTLDPlusOne(hostname)
+	if err != nil {
+		return hostname
+	}
+
+	return domain
+}
+
+// openSeedRegisteredDomains loads the seed registered domains recorded in the job directory
+// by the previous sessions, and opens the file to record the new ones.
+func openSeedRegisteredDomains(jobPath string) error {
+	path := filepath.Join(jobPath, seedRegisteredDomainsFile)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if domain := strings.TrimSpace(scanner.Text()); domain != "" {
+			seedRegisteredDomains.Store(domain, struct{}{})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return err
+	}
+
+	seedRegisteredDomainsLog.Lock()
+	seedRegisteredDomainsLog.file = file
+	seedRegisteredDomainsLog.Unlock()
+
+	return nil
+}
+
+// closeSeedRegisteredDomains closes the file opened by openSeedRegisteredDomains, if any.
+func closeSeedRegisteredDomains() error {
+	seedRegisteredDomainsLog.Lock()
+	defer seedRegisteredDomainsLog.Unlock()
+
+	if seedRegisteredDomainsLog.file == nil {
+		return nil
+	}
+
+	err := seedRegisteredDomainsLog.file.Close()
+	seedRegisteredDomainsLog.file = nil
+
+	return err
+}
+
+// recordSeedRegisteredDomain remembers the registered domain of the item if it's an original seed.
+func recordSeedRegisteredDomain(item *models.Item) {
+	if !item.IsSeed() || item.GetURL().GetHops() != 0 {
+		return
+	}
+
+	domain := registeredDomain(item.GetURL().String())
+	if domain == "" {
+		return
+	}
+
+	if _, known := seedRegisteredDomains.LoadOrStore(domain, struct{}{}); known {
+		return
+	}
+
+	seedRegisteredDomainsLog.Lock()
+	defer seedRegisteredDomainsLog.Unlock()
+
+	if seedRegisteredDomainsLog.file != nil {
+		if _, err := seedRegisteredDomainsLog.file.WriteString(domain + "\n"); err != nil {
+			logger.Error("unable to record seed registered domain", "err", err.Error(), "domain", domain)
+		}
+	}
+}
+
+// filterRegisteredDomain drops the outlinks whose registered domain isn't the one of a seed.
+func filterRegisteredDomain(outlinks []*models.Item) []*models.Item {
+	filtered := outlinks[:0]
+	for _, outlink := range outlinks {
+		domain := registeredDomain(outlink.GetURL().Raw)
+		if domain == "" {
+			continue
+		}
+
+		if _, isSeedDomain := seedRegisteredDomains.Load(domain); isSeedDomain {
+			filtered = append(filtered, outlink)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,77 @@
+package postprocessor
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func TestFilterRegisteredDomain(t *testing.T) {
+	newItem := func(rawURL string, hops int) *models.Item {
+		URL := &models.URL{Raw: rawURL, Hops: hops}
+		if err := URL.Parse(); err != nil {
+			t.Fatalf("unable to parse %s: %s", rawURL, err)
+		}
+		return models.NewItem(uuid.New().String(), URL, "")
+	}
+
+	seed := newItem("https://example.com/", 0)
+	recordSeedRegisteredDomain(seed)
+	defer seedRegisteredDomains.Delete("example.com")
+
+	outlinks := []*models.Item{
+		newItem("https://example.com/about", 1),
+		newItem("https://subdomain.example.com/page", 1),
+		newItem("https://other.com/", 1),
+		newItem("https://example.com.evil.net/", 1),
+	}
+
+	filtered := filterRegisteredDomain(outlinks)
+	if len(filtered) != 2 {
+		t.Fatalf("expected 2 outlinks, got %d", len(filtered))
+	}
+
+	for i, expected := range []string{"https://example.com/about", "https://subdomain.example.com/page"} {
+		if filtered[i].GetURL().Raw != expected {
+			t.Errorf("expected %s, got %s", expected, filtered[i].GetURL().Raw)
+		}
+	}
+
+	// The registered domain of a page that isn't a seed isn't allowed
+	filtered = filterRegisteredDomain([]*models.Item{newItem("https://blog.example.org/", 2), newItem("https://example.com/contact", 2)})
+	if len(filtered) != 1 || filtered[0].GetURL().Raw != "https://example.com/contact" {
+		t.Errorf("expected only the example.com outlink, got %d outlinks", len(filtered))
+	}
+}
+
+func TestSeedRegisteredDomainsSurviveRestarts(t *testing.T) {
+	jobPath := t.TempDir()
+	defer seedRegisteredDomains.Delete("example.net")
+
+	if err := openSeedRegisteredDomains(jobPath); err != nil {
+		t.Fatalf("unable to open seed registered domains: %s", err)
+	}
+
+	URL := &models.URL{Raw: "https://www.example.net/"}
+	if err := URL.Parse(); err != nil {
+		t.Fatalf("unable to parse URL: %s", err)
+	}
+	recordSeedRegisteredDomain(models.NewItem(uuid.New().String(), URL, ""))
+
+	if err := closeSeedRegisteredDomains(); err != nil {
+		t.Fatalf("unable to close seed registered domains: %s", err)
+	}
+
+	// Simulate a restart
+	seedRegisteredDomains.Delete("example.net")
+
+	if err := openSeedRegisteredDomains(jobPath); err != nil {
+		t.Fatalf("unable to reopen seed registered domains: %s", err)
+	}
+	defer closeSeedRegisteredDomains()
+
+	if _, ok := seedRegisteredDomains.Load("example.net"); !ok {
+		t.Error("expected the seed registered domain to be loaded from the job directory")
+	}
+}
@@ -0,0 +1,124 @@
+package postprocessor
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// LinkScorer ranks a discovered URL by how likely it is to be valuable to the crawl.
+// Higher scores are better: the score is the priority of the outlink in the local queue,
+// and outlinks are handed to the source in descending score order. The scorer in use is
+// set for the whole process with SetLinkScorer.
+type LinkScorer interface {
+	Score(parent *url.URL, discovered *url.URL, anchorText string) float64
+}
+
+var (
+	linkScorerMu sync.RWMutex
+	linkScorer   LinkScorer = DefaultLinkScorer{}
+
+	genericAnchorWords = map[string]struct{}{
+		"click": {}, "here": {}, "more": {}, "read": {}, "link": {}, "this": {},
+		"next": {}, "previous": {}, "prev": {}, "page": {}, "see": {}, "go": {},
+		"home": {}, "back": {}, "top": {}, "continue": {}, "view": {},
+	}
+)
+
+// SetLinkScorer replaces the scorer used to rank outlinks, passing nil restores the default scorer.
+// The scorer is a package-level global shared by the whole process, not a per-crawl setting.
+func SetLinkScorer(scorer LinkScorer) {
+	linkScorerMu.Lock()
+	defer linkScorerMu.Unlock()
+
+	if scorer == nil {
+		scorer = DefaultLinkScorer{}
+	}
+
+	linkScorer = scorer
+}
+
+func getLinkScorer() LinkScorer {
+	linkScorerMu.RLock()
+	defer linkScorerMu.RUnlock()
+
+	return linkScorer
+}
+
+// DefaultLinkScorer favors URLs with short paths, on the same domain as their parent
+// and with an anchor text that contains non-generic words.
+type DefaultLinkScorer struct{}
+
+// Score implements LinkScorer
+func (DefaultLinkScorer) Score(parent *url.URL, discovered *url.URL, anchorText string) float64 {
+	if discovered == nil {
+		return 0
+	}
+
+	score := 1.0
+
+	// Shorter paths are more likely to be hub pages
+	segments := 0
+	for _, segment := range strings.Split(discovered.Path, "/") {
+		if segment != "" {
+			segments++
+		}
+	}
+	score += 1 / float64(1+segments)
+
+	// Links that stay on the parent's domain are more likely to be relevant
+	if parent != nil {
+		parentHost := strings.TrimPrefix(parent.Hostname(), "www.")
+		discoveredHost := strings.TrimPrefix(discovered.Hostname(), "www.")
+		if discoveredHost == parentHost || strings.HasSuffix(discoveredHost, "."+parentHost) {
+			score += 1
+		}
+	}
+
+	// Descriptive anchor texts are a good hint that the link points to actual content
+	for _, word := range strings.Fields(strings.ToLower(anchorText)) {
+		word = strings.Trim(word, ".,;:!?\"'()[]")
+		if len(word) < 3 {
+			continue
+		}
+
+		if _, generic := genericAnchorWords[word]; !generic {
+			score += 0.5
+			break
+		}
+	}
+
+	return score
+}
+
+// sortOutlinksByScore scores the outlinks, the score being stored as their priority,
+// and sorts them in place from the highest score to the lowest.
+func sortOutlinksByScore(parent *models.URL, outlinks []*models.Item) {
+	if len(outlinks) == 0 {
+		return
+	}
+
+	scorer := getLinkScorer()
+
+	var parentURL *url.URL
+	if parent != nil {
+		parentURL = parent.GetParsed()
+	}
+
+	for _, outlink := range outlinks {
+		discovered, err := url.Parse(outlink.GetURL().Raw)
+		if err != nil {
+			outlink.GetURL().Priority = 0
+			continue
+		}
+
+		outlink.GetURL().Priority = scorer.Score(parentURL, discovered, outlink.GetURL().AnchorText)
+	}
+
+	sort.SliceStable(outlinks, func(i, j int) bool {
+		return outlinks[i].GetURL().Priority > outlinks[j].GetURL().Priority
+	})
+}
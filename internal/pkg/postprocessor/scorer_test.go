@@ -0,0 +1,110 @@
+package postprocessor
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func TestDefaultLinkScorer(t *testing.T) {
+	parent, _ := url.Parse("https://example.com/index.html")
+	scorer := DefaultLinkScorer{}
+
+	tests := []struct {
+		name       string
+		better     string
+		betterText string
+		worse      string
+		worseText  string
+	}{
+		{
+			name:   "same domain beats external domain",
+			better: "https://example.com/a/b",
+			worse:  "https://other.com/a/b",
+		},
+		{
+			name:   "subdomain counts as same domain",
+			better: "https://blog.example.com/a/b",
+			worse:  "https://other.com/a/b",
+		},
+		{
+			name:   "shorter path beats longer path",
+			better: "https://example.com/a",
+			worse:  "https://example.com/a/b/c/d",
+		},
+		{
+			name:       "descriptive anchor beats generic anchor",
+			better:     "https://example.com/a",
+			betterText: "Annual report 2024",
+			worse:      "https://example.com/a",
+			worseText:  "click here",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better, _ := url.Parse(tt.better)
+			worse, _ := url.Parse(tt.worse)
+
+			betterScore := scorer.Score(parent, better, tt.betterText)
+			worseScore := scorer.Score(parent, worse, tt.worseText)
+			if betterScore <= worseScore {
+				t.Errorf("expected %s (%f) to score higher than %s (%f)", tt.better, betterScore, tt.worse, worseScore)
+			}
+		})
+	}
+}
+
+type reverseScorer struct{}
+
+func (reverseScorer) Score(_ *url.URL, discovered *url.URL, _ string) float64 {
+	return float64(len(discovered.Path))
+}
+
+func TestSortOutlinksByScore(t *testing.T) {
+	parent := &models.URL{Raw: "https://example.com/"}
+	if err := parent.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	outlinks := []*models.Item{
+		models.NewItem("1", &models.URL{Raw: "https://other.com/a/b/c"}, ""),
+		models.NewItem("2", &models.URL{Raw: "https://example.com/a"}, ""),
+	}
+
+	sortOutlinksByScore(parent, outlinks)
+	if outlinks[0].GetID() != "2" {
+		t.Errorf("expected same domain outlink first, got %s", outlinks[0].GetURL().Raw)
+	}
+
+	SetLinkScorer(reverseScorer{})
+	defer SetLinkScorer(nil)
+
+	sortOutlinksByScore(parent, outlinks)
+	if outlinks[0].GetID() != "1" {
+		t.Errorf("expected custom scorer to put longest path first, got %s", outlinks[0].GetURL().Raw)
+	}
+}
+
+func TestSortOutlinksByScoreAnchorText(t *testing.T) {
+	parent := &models.URL{Raw: "https://example.com/"}
+	if err := parent.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	outlinks := []*models.Item{
+		models.NewItem("1", &models.URL{Raw: "https://example.com/a", AnchorText: "click here"}, ""),
+		models.NewItem("2", &models.URL{Raw: "https://example.com/b", AnchorText: "Annual report 2024"}, ""),
+	}
+
+	sortOutlinksByScore(parent, outlinks)
+	if outlinks[0].GetID() != "2" {
+		t.Errorf("expected the descriptive anchor text first, got %s", outlinks[0].GetURL().Raw)
+	}
+
+	// The score is kept as the priority of the outlink in the queue
+	if outlinks[0].GetURL().Priority <= outlinks[1].GetURL().Priority || outlinks[1].GetURL().Priority == 0 {
+		t.Errorf("unexpected priorities %f and %f", outlinks[0].GetURL().Priority, outlinks[1].GetURL().Priority)
+	}
+}
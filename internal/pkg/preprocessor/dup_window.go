@@ -0,0 +1,77 @@
+package preprocessor
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// ShortTermDupWindow remembers the URLs dispatched recently, so that a URL found by two
+// concurrent extractions isn't fetched twice before the seencheck catches up.
+// It holds at most size URLs, each for ttl.
+type ShortTermDupWindow struct {
+	sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	order   *list.List // front is the most recently dispatched URL
+	nowFunc func() time.Time
+}
+
+type dupWindowEntry struct {
+	URL          string
+	dispatchedAt time.Time
+}
+
+// NewShortTermDupWindow returns a ShortTermDupWindow holding up to size URLs for ttl.
+func NewShortTermDupWindow(size int, ttl time.Duration) *ShortTermDupWindow {
+	return &ShortTermDupWindow{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		nowFunc: time.Now,
+	}
+}
+
+// Seen returns true if the URL was dispatched within the window.
+func (w *ShortTermDupWindow) Seen(URL string) bool {
+	w.Lock()
+	defer w.Unlock()
+
+	w.expire(w.nowFunc())
+
+	_, ok := w.entries[URL]
+	return ok
+}
+
+// Add records the URL as dispatched now.
+func (w *ShortTermDupWindow) Add(URL string) {
+	w.Lock()
+	defer w.Unlock()
+
+	now := w.nowFunc()
+	w.expire(now)
+
+	if entry, ok := w.entries[URL]; ok {
+		entry.Value.(*dupWindowEntry).dispatchedAt = now
+		w.order.MoveToFront(entry)
+		return
+	}
+
+	w.entries[URL] = w.order.PushFront(&dupWindowEntry{URL: URL, dispatchedAt: now})
+
+	if w.order.Len() > w.size {
+		oldest := w.order.Back()
+		w.order.Remove(oldest)
+		delete(w.entries, oldest.Value.(*dupWindowEntry).URL)
+	}
+}
+
+// expire removes the URLs older than the window, the caller must hold the lock.
+func (w *ShortTermDupWindow) expire(now time.Time) {
+	for oldest := w.order.Back(); oldest != nil && now.Sub(oldest.Value.(*dupWindowEntry).dispatchedAt) > w.ttl; oldest = w.order.Back() {
+		w.order.Remove(oldest)
+		delete(w.entries, oldest.Value.(*dupWindowEntry).URL)
+	}
+}
@@ -0,0 +1,44 @@
+package preprocessor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShortTermDupWindow(t *testing.T) {
+	now := time.Now()
+	w := NewShortTermDupWindow(2, 5*time.Minute)
+	w.nowFunc = func() time.Time { return now }
+
+	if w.Seen("https://example.com/a") {
+		t.Error("URL not dispatched yet shouldn't be seen")
+	}
+
+	// Checking a URL doesn't record it, only its dispatch does
+	if w.Seen("https://example.com/a") {
+		t.Error("URL checked but not dispatched shouldn't be seen")
+	}
+
+	w.Add("https://example.com/a")
+	if !w.Seen("https://example.com/a") {
+		t.Error("URL dispatched within the window should be seen")
+	}
+
+	// The URLs expire after the window duration
+	now = now.Add(6 * time.Minute)
+	if w.Seen("https://example.com/a") {
+		t.Error("URL shouldn't be seen after the window duration")
+	}
+
+	// The oldest URL is evicted when the window is full
+	w.Add("https://example.com/a")
+	w.Add("https://example.com/b")
+	w.Add("https://example.com/c")
+	if w.Seen("https://example.com/a") {
+		t.Error("oldest URL should have been evicted from the full window")
+	}
+
+	if !w.Seen("https://example.com/c") {
+		t.Error("recent URL should still be in the window")
+	}
+}
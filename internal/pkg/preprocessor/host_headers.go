@@ -0,0 +1,59 @@
+package preprocessor
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// hostHeaders holds the headers added at runtime (e.g. from the control socket) for
+// specific hosts, keyed by lowercased hostname
+var hostHeaders = struct {
+	sync.RWMutex
+	headers map[string]http.Header
+}{headers: make(map[string]http.Header)}
+
+// AddHostHeaders sets headers on every request sent to host from now on. Headers
+// previously added for the same host and name are replaced.
+func AddHostHeaders(host string, headers map[string]string) error {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if host == "" {
+		return errors.New("host is required")
+	}
+
+	if len(headers) == 0 {
+		return errors.New("at least one header is required")
+	}
+
+	for name := range headers {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("header names can't be empty")
+		}
+	}
+
+	hostHeaders.Lock()
+	defer hostHeaders.Unlock()
+
+	if _, ok := hostHeaders.headers[host]; !ok {
+		hostHeaders.headers[host] = make(http.Header, len(headers))
+	}
+
+	for name, value := range headers {
+		hostHeaders.headers[host].Set(strings.TrimSpace(name), value)
+	}
+
+	return nil
+}
+
+// applyHostHeaders sets on the request the headers added for the URL's host, if any
+func applyHostHeaders(req *http.Request, URL *models.URL) {
+	hostHeaders.RLock()
+	defer hostHeaders.RUnlock()
+
+	for name, values := range hostHeaders.headers[strings.ToLower(URL.GetParsed().Hostname())] {
+		req.Header[name] = append([]string(nil), values...)
+	}
+}
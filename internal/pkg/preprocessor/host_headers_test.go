@@ -0,0 +1,60 @@
+package preprocessor
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func TestApplyHostHeaders(t *testing.T) {
+	defer func() {
+		hostHeaders.Lock()
+		hostHeaders.headers = make(map[string]http.Header)
+		hostHeaders.Unlock()
+	}()
+
+	if err := AddHostHeaders("Example.com", map[string]string{"X-Token": "abc", "Accept-Language": "fr"}); err != nil {
+		t.Fatalf("unable to add host headers: %s", err)
+	}
+
+	if err := AddHostHeaders("example.com", map[string]string{"x-token": "def"}); err != nil {
+		t.Fatalf("unable to add host headers: %s", err)
+	}
+
+	if err := AddHostHeaders("", map[string]string{"X-Token": "abc"}); err == nil {
+		t.Error("expected an error for an empty host")
+	}
+
+	tests := []struct {
+		rawURL         string
+		token          string
+		acceptLanguage string
+	}{
+		{"https://example.com/page", "def", "fr"},
+		{"http://EXAMPLE.com:8080/", "def", "fr"},
+		{"https://sub.example.com/", "", ""},
+	}
+
+	for _, tt := range tests {
+		URL := &models.URL{Raw: tt.rawURL}
+		if err := URL.Parse(); err != nil {
+			t.Fatalf("unable to parse %s: %s", tt.rawURL, err)
+		}
+
+		req, err := http.NewRequest(http.MethodGet, tt.rawURL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		applyHostHeaders(req, URL)
+
+		if got := req.Header.Get("X-Token"); got != tt.token {
+			t.Errorf("%s: expected X-Token %q, got %q", tt.rawURL, tt.token, got)
+		}
+
+		if got := req.Header.Get("Accept-Language"); got != tt.acceptLanguage {
+			t.Errorf("%s: expected Accept-Language %q, got %q", tt.rawURL, tt.acceptLanguage, got)
+		}
+	}
+}
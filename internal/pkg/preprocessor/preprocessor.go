@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/internetarchive/Zeno/internal/pkg/config"
 	"github.com/internetarchive/Zeno/internal/pkg/controler/pause"
@@ -40,9 +41,11 @@ type preprocessor struct {
 }
 
 var (
-	globalPreprocessor *preprocessor
-	once               sync.Once
-	logger             *log.FieldedLogger
+	globalPreprocessor        *preprocessor
+	globalReachabilityChecker *HostReachabilityChecker
+	globalDupWindow           *ShortTermDupWindow
+	once                      sync.Once
+	logger                    *log.FieldedLogger
 )
 
 // Start initializes the internal preprocessor structure and start routines, should only be called once and returns an error if called more than once
@@ -64,6 +67,25 @@ func Start(inputChan, outputChan chan *models.Item) error {
 			inputCh:  inputChan,
 			outputCh: outputChan,
 		}
+
+		// The check dials directly, it can't tell if a host is reachable through the proxy
+		if config.Get().PingCheckNewHosts && config.Get().Proxy != "" {
+			logger.Warn("--ping-check-new-hosts is ignored when using a proxy")
+		} else if config.Get().PingCheckNewHosts {
+			network := "tcp"
+			if config.Get().DisableIPv4 {
+				network = "tcp6"
+			} else if config.Get().DisableIPv6 {
+				network = "tcp4"
+			}
+
+			globalReachabilityChecker = NewHostReachabilityChecker(5*time.Second, config.Get().DeadHostTTL, network)
+		}
+
+		if config.Get().DupWindowSize > 0 && config.Get().DupWindowDuration > 0 {
+			globalDupWindow = NewShortTermDupWindow(config.Get().DupWindowSize, config.Get().DupWindowDuration)
+		}
+
 		logger.Debug("initialized")
 		for i := 0; i < config.Get().WorkersCount; i++ {
 			globalPreprocessor.wg.Add(1)
@@ -129,17 +151,44 @@ func (p *preprocessor) worker(workerID string) {
 
 				preprocess(workerID, seed)
 
+				// Collected before sending the seed, the archiver updates its items once received
+				dispatchedURLs := dispatchedURLs(seed)
+
 				select {
 				case <-p.ctx.Done():
 					logger.Debug("aborting seed due to stop", "seed", seed.GetShortID())
 					return
 				case p.outputCh <- seed:
+					for _, URL := range dispatchedURLs {
+						globalDupWindow.Add(URL)
+					}
 				}
 			}
 		}
 	}
 }
 
+// dispatchedURLs returns the URLs of the seed that will be fetched by the archiver, to be
+// recorded in the short-term duplicate window once the seed is sent to it
+func dispatchedURLs(seed *models.Item) (URLs []string) {
+	if globalDupWindow == nil {
+		return nil
+	}
+
+	items, err := seed.GetNodesAtLevel(seed.GetMaxDepth())
+	if err != nil {
+		panic(err)
+	}
+
+	for i := range items {
+		if items[i].GetStatus() == models.ItemPreProcessed {
+			URLs = append(URLs, items[i].GetURL().String())
+		}
+	}
+
+	return URLs
+}
+
 func preprocess(workerID string, seed *models.Item) {
 	logger := log.NewFieldedLogger(&log.Fields{
 		"component": "preprocessor.preprocess",
@@ -216,6 +265,41 @@ func preprocess(workerID string, seed *models.Item) {
 			return
 		}
 
+		// Skip URLs nested too deep in the site structure
+		if config.Get().MaxPathDepth > 0 && pathDepth(items[i].GetURL().GetParsed()) > config.Get().MaxPathDepth {
+			logger.Debug("URL excluded (path too deep)",
+				"item_id", items[i].GetShortID(),
+				"seed_id", seed.GetShortID(),
+				"url", items[i].GetURL().String())
+
+			if items[i].IsChild() || items[i].IsRedirection() {
+				items[i].GetParent().RemoveChild(items[i])
+				continue
+			}
+
+			items[i].SetStatus(models.ItemCompleted)
+			return
+		}
+
+		// If enabled, make sure that the host is reachable before crawling it for the first time
+		if globalReachabilityChecker != nil {
+			parsed := items[i].GetURL().GetParsed()
+			if !globalReachabilityChecker.IsReachable(parsed.Hostname(), parsed.Port()) {
+				logger.Debug("URL excluded (host unreachable)",
+					"item_id", items[i].GetShortID(),
+					"seed_id", seed.GetShortID(),
+					"url", items[i].GetURL().String())
+
+				if items[i].IsChild() || items[i].IsRedirection() {
+					items[i].GetParent().RemoveChild(items[i])
+					continue
+				}
+
+				items[i].SetStatus(models.ItemFailed)
+				return
+			}
+		}
+
 		// If we are processing assets, then we need to remove childs that are just domains
 		// (which means that they are not assets, but false positives)
 		if items[i].IsChild() {
@@ -259,6 +343,17 @@ func preprocess(workerID string, seed *models.Item) {
 		panic(err)
 	}
 
+	// Skip the URLs dispatched to the archiver within the short-term duplicate window,
+	// e.g. found by two concurrent extractions before either was seencheck'd
+	if globalDupWindow != nil {
+		for i := range items {
+			if items[i].GetStatus() == models.ItemFresh && globalDupWindow.Seen(items[i].GetURL().String()) {
+				logger.Debug("URL skipped (dispatched recently)", "item_id", items[i].GetShortID(), "seed_id", seed.GetShortID(), "url", items[i].GetURL().String())
+				items[i].SetStatus(models.ItemSeen)
+			}
+		}
+	}
+
 	// Remove any item that is not fresh from the list
 	for i := len(items) - 1; i >= 0; i-- {
 		if items[i].GetStatus() != models.ItemFresh {
@@ -281,8 +376,8 @@ func preprocess(workerID string, seed *models.Item) {
 			continue
 		}
 
-		// Apply configured User-Agent
-		req.Header.Set("User-Agent", config.Get().UserAgent)
+		// Apply configured User-Agent, host-specific ones taking precedence
+		req.Header.Set("User-Agent", userAgentFor(items[i].GetURL()))
 
 		switch {
 		case tiktok.IsTikTokURL(items[i].GetURL()):
@@ -299,6 +394,9 @@ func preprocess(workerID string, seed *models.Item) {
 			truthsocial.AddAccountsAPIHeaders(req)
 		}
 
+		// Headers added for the host at runtime override the ones above
+		applyHostHeaders(req, items[i].GetURL())
+
 		items[i].GetURL().SetRequest(req)
 		items[i].SetStatus(models.ItemPreProcessed)
 	}
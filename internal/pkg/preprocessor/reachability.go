@@ -0,0 +1,129 @@
+package preprocessor
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// HostReachabilityChecker checks that a host accepts TCP connections before it is crawled
+// for the first time. The checks run in the background: a host is considered reachable
+// until its check fails. Reachable hosts are only checked once, unreachable hosts are kept
+// in a dead-host cache until their TTL expires.
+type HostReachabilityChecker struct {
+	timeout time.Duration
+	deadTTL time.Duration
+	network string // tcp, or tcp4/tcp6 when an IP family is disabled
+
+	mu        sync.Mutex
+	reachable map[string]struct{}
+	checking  map[string]struct{}
+	dead      map[string]time.Time // host -> expiration of the dead entry
+	wg        sync.WaitGroup       // ongoing checks
+
+	// dialFunc is used to open the TCP connections, it can be overridden for testing.
+	dialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+	// nowFunc is used to fetch the current time, it can be overridden for testing.
+	nowFunc func() time.Time
+}
+
+// NewHostReachabilityChecker creates a new HostReachabilityChecker using the given dial timeout
+// and dead-host cache TTL. The network is "tcp", or "tcp4" or "tcp6" to only dial one IP family.
+func NewHostReachabilityChecker(timeout, deadTTL time.Duration, network string) *HostReachabilityChecker {
+	dialer := &net.Dialer{}
+
+	return &HostReachabilityChecker{
+		timeout:   timeout,
+		deadTTL:   deadTTL,
+		network:   network,
+		reachable: make(map[string]struct{}),
+		checking:  make(map[string]struct{}),
+		dead:      make(map[string]time.Time),
+		dialFunc:  dialer.DialContext,
+		nowFunc:   time.Now,
+	}
+}
+
+// IsReachable returns false if the host is known to be dead, true otherwise. It never blocks:
+// the first call for a host starts its check in the background. If port is empty, ports 80
+// and 443 are tried and the host is reachable if any of them accepts the connection.
+func (c *HostReachabilityChecker) IsReachable(host, port string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.reachable[host]; ok {
+		return true
+	}
+
+	if _, ok := c.checking[host]; ok {
+		return true
+	}
+
+	if expiration, ok := c.dead[host]; ok {
+		if c.nowFunc().Before(expiration) {
+			return false
+		}
+		delete(c.dead, host)
+	}
+
+	ports := []string{"80", "443"}
+	if port != "" {
+		ports = []string{port}
+	}
+
+	c.checking[host] = struct{}{}
+	c.wg.Add(1)
+	go c.check(host, ports)
+
+	return true
+}
+
+// check dials the host and records the result.
+func (c *HostReachabilityChecker) check(host string, ports []string) {
+	defer c.wg.Done()
+
+	ok := c.dial(host, ports)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.checking, host)
+	if ok {
+		c.reachable[host] = struct{}{}
+	} else {
+		c.dead[host] = c.nowFunc().Add(c.deadTTL)
+	}
+}
+
+// Wait waits for the ongoing checks to finish.
+func (c *HostReachabilityChecker) Wait() {
+	c.wg.Wait()
+}
+
+// dial tries to connect to all the ports concurrently and returns true as soon as one succeeds.
+func (c *HostReachabilityChecker) dial(host string, ports []string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	results := make(chan bool, len(ports))
+	for _, port := range ports {
+		go func(port string) {
+			conn, err := c.dialFunc(ctx, c.network, net.JoinHostPort(host, port))
+			if err != nil {
+				results <- false
+				return
+			}
+			conn.Close()
+			results <- true
+		}(port)
+	}
+
+	for range ports {
+		if <-results {
+			return true
+		}
+	}
+
+	return false
+}
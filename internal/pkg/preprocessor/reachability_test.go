@@ -0,0 +1,79 @@
+package preprocessor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHostReachabilityChecker(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	checker := NewHostReachabilityChecker(time.Second, time.Hour, "tcp4")
+	if !checker.IsReachable("127.0.0.1", port) {
+		t.Error("expected host being checked to be reachable")
+	}
+	checker.Wait()
+
+	if !checker.IsReachable("127.0.0.1", port) {
+		t.Error("expected listening host to be reachable")
+	}
+
+	listener.Close()
+
+	// Reachable hosts are only checked once
+	if !checker.IsReachable("127.0.0.1", port) {
+		t.Error("expected reachable host to be cached")
+	}
+}
+
+func TestHostReachabilityCheckerDeadHostTTL(t *testing.T) {
+	var dials atomic.Int32
+	now := time.Now()
+
+	checker := NewHostReachabilityChecker(time.Second, time.Minute, "tcp6")
+	checker.nowFunc = func() time.Time { return now }
+	checker.dialFunc = func(_ context.Context, network, _ string) (net.Conn, error) {
+		if network != "tcp6" {
+			t.Errorf("expected a tcp6 dial, got %s", network)
+		}
+		dials.Add(1)
+		return nil, errors.New("connection refused")
+	}
+
+	// The check doesn't block, the host is considered reachable until it fails
+	if !checker.IsReachable("dead.example", "") {
+		t.Fatal("expected host being checked to be reachable")
+	}
+	checker.Wait()
+
+	if checker.IsReachable("dead.example", "") {
+		t.Fatal("expected host to be unreachable")
+	}
+
+	// Both port 80 and 443 have been tried
+	if dials.Load() != 2 {
+		t.Fatalf("expected 2 dials, got %d", dials.Load())
+	}
+
+	if checker.IsReachable("dead.example", "") || dials.Load() != 2 {
+		t.Fatalf("expected dead host to be served from the cache, got %d dials", dials.Load())
+	}
+
+	now = now.Add(2 * time.Minute)
+
+	checker.IsReachable("dead.example", "")
+	checker.Wait()
+	if checker.IsReachable("dead.example", "") || dials.Load() != 4 {
+		t.Fatalf("expected dead host to be checked again after its TTL, got %d dials", dials.Load())
+	}
+}
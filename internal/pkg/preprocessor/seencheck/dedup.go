@@ -0,0 +1,56 @@
+package seencheck
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// SeedDeduplicator drops the seeds already seen by it, once normalized, before they are queued.
+// It is safe for concurrent use, so multiple seed sources can share one.
+type SeedDeduplicator struct {
+	seen sync.Map // map[[sha256.Size]byte]struct{}
+
+	// normalize is the normalization applied by the preprocessor (preprocessor.NormalizeURL),
+	// it's passed in because the preprocessor imports this package
+	normalize func(URL *models.URL) error
+
+	// inventory, if set, reports the normalized seeds crawled by previous runs
+	inventory func(URL *models.URL) bool
+}
+
+// NewSeedDeduplicator returns a SeedDeduplicator normalizing the seeds with normalize. inventory
+// is optional and can be used to also drop the seeds already crawled (e.g. IsSeedSeen).
+func NewSeedDeduplicator(normalize func(URL *models.URL) error, inventory func(URL *models.URL) bool) *SeedDeduplicator {
+	return &SeedDeduplicator{
+		normalize: normalize,
+		inventory: inventory,
+	}
+}
+
+// Dedupe returns the seeds that weren't seen before and the number of duplicates dropped.
+// Seeds that can't be normalized are kept as-is, they will be rejected further down the pipeline.
+func (d *SeedDeduplicator) Dedupe(seeds []string) (unique []string, dups int) {
+	for _, seed := range seeds {
+		URL := &models.URL{Raw: seed}
+		if err := d.normalize(URL); err != nil {
+			unique = append(unique, seed)
+			continue
+		}
+
+		if _, loaded := d.seen.LoadOrStore(sha256.Sum256([]byte(URL.String())), struct{}{}); loaded {
+			dups++
+			continue
+		}
+
+		if d.inventory != nil && d.inventory(URL) {
+			dups++
+			continue
+		}
+
+		unique = append(unique, seed)
+	}
+
+	return unique, dups
+}
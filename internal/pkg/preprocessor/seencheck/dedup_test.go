@@ -0,0 +1,65 @@
+package seencheck_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/internetarchive/Zeno/internal/pkg/preprocessor"
+	"github.com/internetarchive/Zeno/internal/pkg/preprocessor/seencheck"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func normalize(URL *models.URL) error {
+	return preprocessor.NormalizeURL(URL, nil)
+}
+
+func TestSeedDeduplicator(t *testing.T) {
+	crawled := "https://example.net/"
+	d := seencheck.NewSeedDeduplicator(normalize, func(URL *models.URL) bool { return URL.String() == crawled })
+
+	unique, dups := d.Dedupe([]string{
+		"https://example.com/page",
+		"https://example.com/page#section",
+		"https://EXAMPLE.com/page",
+		"https://example.net/",
+		"https://example.org/",
+	})
+
+	expected := []string{"https://example.com/page", "https://example.org/"}
+	if !slices.Equal(unique, expected) {
+		t.Errorf("expected %v, got %v", expected, unique)
+	}
+
+	if dups != 3 {
+		t.Errorf("expected 3 duplicates, got %d", dups)
+	}
+
+	// Seeds are remembered across calls
+	unique, dups = d.Dedupe([]string{"https://example.org/", "https://example.org/other"})
+	if len(unique) != 1 || dups != 1 {
+		t.Errorf("expected 1 new seed and 1 duplicate, got %v and %d", unique, dups)
+	}
+}
+
+func TestSeedDeduplicatorSeencheckInventory(t *testing.T) {
+	if err := seencheck.Start(t.TempDir()); err != nil {
+		t.Fatalf("unable to start seencheck: %s", err)
+	}
+	defer seencheck.Close()
+
+	// Seencheck a seed the way the preprocessor does, once normalized
+	URL := &models.URL{Raw: "https://EXAMPLE.com/page?b=2&a=1#section"}
+	if err := normalize(URL); err != nil {
+		t.Fatalf("unable to normalize URL: %s", err)
+	}
+	if err := seencheck.SeencheckItem(models.NewItem(uuid.New().String(), URL, "")); err != nil {
+		t.Fatalf("unable to seencheck item: %s", err)
+	}
+
+	d := seencheck.NewSeedDeduplicator(normalize, seencheck.IsSeedSeen)
+	unique, dups := d.Dedupe([]string{"https://example.com/page?b=2&a=1", "https://example.org/"})
+	if !slices.Equal(unique, []string{"https://example.org/"}) || dups != 1 {
+		t.Errorf("expected the seencheck'd seed to be dropped, got %v and %d duplicates", unique, dups)
+	}
+}
@@ -118,3 +118,14 @@ func SeencheckItem(item *models.Item) error {
 
 	return nil
 }
+
+// IsSeedSeen returns true if the URL was already seencheck'd as a seed, without marking it as seen.
+// The URL must have been normalized (preprocessor.NormalizeURL), like the seeds are before being seencheck'd.
+func IsSeedSeen(URL *models.URL) bool {
+	h := fnv.New64a()
+	h.Write([]byte(URL.String()))
+
+	found, foundType := isSeen(strconv.FormatUint(h.Sum64(), 10))
+
+	return found && foundType == "seed"
+}
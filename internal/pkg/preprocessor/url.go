@@ -71,3 +71,14 @@ func NormalizeURL(URL *models.URL, parentURL *models.URL) (err error) {
 
 	return URL.Parse()
 }
+
+// pathDepth returns the number of non-empty segments in the path of the URL.
+func pathDepth(URL *url.URL) (depth int) {
+	for _, segment := range strings.Split(URL.Path, "/") {
+		if segment != "" {
+			depth++
+		}
+	}
+
+	return depth
+}
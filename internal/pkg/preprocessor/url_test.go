@@ -1,6 +1,7 @@
 package preprocessor
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/internetarchive/Zeno/pkg/models"
@@ -89,3 +90,29 @@ func TestNormalizeURL(t *testing.T) {
 		})
 	}
 }
+
+func TestPathDepth(t *testing.T) {
+	tests := []struct {
+		rawURL string
+		want   int
+	}{
+		{rawURL: "https://example.com", want: 0},
+		{rawURL: "https://example.com/", want: 0},
+		{rawURL: "https://example.com/a/b/c", want: 3},
+		{rawURL: "https://example.com/a//b/", want: 2},
+		{rawURL: "https://example.com/a/b?c=/d/e", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rawURL, func(t *testing.T) {
+			u, err := url.Parse(tt.rawURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := pathDepth(u); got != tt.want {
+				t.Errorf("pathDepth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package preprocessor
+
+import (
+	"strings"
+
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+// userAgentFor returns the User-Agent to send to the URL's host: the one given with
+// --host-user-agent for that host if any, the global --user-agent otherwise
+func userAgentFor(URL *models.URL) string {
+	if userAgent, ok := config.Get().HostUserAgents[strings.ToLower(URL.GetParsed().Hostname())]; ok {
+		return userAgent
+	}
+
+	return config.Get().UserAgent
+}
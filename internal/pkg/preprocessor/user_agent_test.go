@@ -0,0 +1,46 @@
+package preprocessor
+
+import (
+	"testing"
+
+	"github.com/internetarchive/Zeno/internal/pkg/config"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func TestUserAgentFor(t *testing.T) {
+	if err := config.InitConfig(); err != nil {
+		t.Fatalf("unable to init config: %s", err)
+	}
+
+	config.Get().UserAgent = "Zeno/test"
+	config.Get().HostUserAgents = map[string]string{
+		"example.com":     "ExampleBot/1.0",
+		"www.example.org": "Mozilla/5.0 (compatible; OrgBot/2.0)",
+	}
+	defer func() {
+		config.Get().UserAgent = ""
+		config.Get().HostUserAgents = nil
+	}()
+
+	tests := []struct {
+		rawURL   string
+		expected string
+	}{
+		{"https://example.com/page", "ExampleBot/1.0"},
+		{"http://EXAMPLE.com:8080/", "ExampleBot/1.0"},
+		{"https://www.example.org/", "Mozilla/5.0 (compatible; OrgBot/2.0)"},
+		{"https://sub.example.com/", "Zeno/test"},
+		{"https://other.net/", "Zeno/test"},
+	}
+
+	for _, tt := range tests {
+		URL := &models.URL{Raw: tt.rawURL}
+		if err := URL.Parse(); err != nil {
+			t.Fatalf("unable to parse %s: %s", tt.rawURL, err)
+		}
+
+		if userAgent := userAgentFor(URL); userAgent != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.rawURL, tt.expected, userAgent)
+		}
+	}
+}
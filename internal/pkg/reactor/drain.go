@@ -0,0 +1,91 @@
+package reactor
+
+import (
+	"context"
+	"time"
+)
+
+// drainPollInterval is how often Drain checks whether the state table is empty
+var drainPollInterval = 100 * time.Millisecond
+
+// Drain stops the reactor from accepting new seeds (ReceiveInsert returns ErrReactorDraining)
+// and waits until all the seeds in the state table are finished, or ctx is cancelled.
+// The reactor keeps refusing new seeds until EndDrain is called. Only the state table is
+// drained: the seeds already claimed from the queue by the sources aren't requeued.
+func Drain(ctx context.Context) error {
+	if globalReactor == nil {
+		return ErrReactorNotInitialized
+	}
+
+	globalReactor.drainMu.Lock()
+	if !globalReactor.draining.Load() {
+		globalReactor.drainEnd = make(chan struct{})
+		globalReactor.draining.Store(true)
+		logger.Info("draining")
+	}
+	globalReactor.drainMu.Unlock()
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if len(GetStateTable()) == 0 {
+			logger.Info("drained")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-globalReactor.ctx.Done():
+			return ErrReactorShuttingDown
+		case <-ticker.C:
+		}
+	}
+}
+
+// EndDrain makes the reactor accept new seeds again.
+func EndDrain() {
+	if globalReactor == nil {
+		return
+	}
+
+	globalReactor.drainMu.Lock()
+	defer globalReactor.drainMu.Unlock()
+
+	if globalReactor.draining.CompareAndSwap(true, false) {
+		close(globalReactor.drainEnd)
+		logger.Info("drain ended")
+	}
+}
+
+// IsDraining returns true if the reactor is refusing new seeds because of Drain.
+func IsDraining() bool {
+	return globalReactor != nil && globalReactor.draining.Load()
+}
+
+// WaitDrainEnd blocks until EndDrain is called, ctx is cancelled or the reactor is stopping.
+// It returns immediately if the reactor isn't draining.
+func WaitDrainEnd(ctx context.Context) error {
+	if globalReactor == nil {
+		return ErrReactorNotInitialized
+	}
+
+	globalReactor.drainMu.Lock()
+	drainEnd := globalReactor.drainEnd
+	draining := globalReactor.draining.Load()
+	globalReactor.drainMu.Unlock()
+
+	if !draining {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-globalReactor.ctx.Done():
+		return ErrReactorShuttingDown
+	case <-drainEnd:
+		return nil
+	}
+}
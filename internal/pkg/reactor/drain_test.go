@@ -0,0 +1,73 @@
+package reactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func TestDrain(t *testing.T) {
+	outputChan := make(chan *models.Item, 1)
+	if err := Start(1, outputChan); err != nil {
+		t.Fatalf("Error starting reactor: %s", err)
+	}
+	defer Stop()
+
+	newSeed := func() *models.Item {
+		item := models.NewItem(uuid.New().String(), &models.URL{Raw: "http://example.com"}, "")
+		item.SetSource(models.ItemSourceInsert)
+		return item
+	}
+
+	seed := newSeed()
+	if err := ReceiveInsert(seed); err != nil {
+		t.Fatalf("Error inserting seed: %s", err)
+	}
+	<-outputChan
+
+	drained := make(chan error)
+	go func() { drained <- Drain(context.Background()) }()
+
+	// Wait for the drain to start
+	for !IsDraining() {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := ReceiveInsert(newSeed()); err != ErrReactorDraining {
+		t.Fatalf("expected ErrReactorDraining, got %v", err)
+	}
+
+	select {
+	case err := <-drained:
+		t.Fatalf("Drain returned before the seed was finished: %v", err)
+	case <-time.After(3 * drainPollInterval):
+	}
+
+	if err := MarkAsFinished(seed); err != nil {
+		t.Fatalf("Error finishing seed: %s", err)
+	}
+
+	select {
+	case err := <-drained:
+		if err != nil {
+			t.Fatalf("Drain returned an error: %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Drain didn't return after the seed was finished")
+	}
+
+	waited := make(chan error)
+	go func() { waited <- WaitDrainEnd(context.Background()) }()
+
+	EndDrain()
+	if err := <-waited; err != nil {
+		t.Fatalf("WaitDrainEnd returned an error: %s", err)
+	}
+
+	if err := ReceiveInsert(newSeed()); err != nil {
+		t.Fatalf("expected the reactor to accept seeds after EndDrain, got %v", err)
+	}
+}
@@ -11,6 +11,8 @@ var (
 	ErrReactorShuttingDown = errors.New("reactor shutting down")
 	// ErrReactorFrozen is the error returned when the reactor is frozen
 	ErrReactorFrozen = errors.New("reactor frozen")
+	// ErrReactorDraining is the error returned when the reactor is draining and doesn't accept new seeds
+	ErrReactorDraining = errors.New("reactor draining")
 
 	// ErrFeedbackItemNotPresent is the error returned when an item was sent to the feedback channel but not found in the state table
 	ErrFeedbackItemNotPresent = errors.New("feedback item not present in state table")
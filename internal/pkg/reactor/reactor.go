@@ -4,6 +4,7 @@ package reactor
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/internetarchive/Zeno/internal/pkg/log"
@@ -21,6 +22,9 @@ type reactor struct {
 	output       chan *models.Item  // Output channel
 	stateTable   sync.Map           // State table for tracking seeds by UUID
 	wg           sync.WaitGroup     // WaitGroup to manage goroutines
+	draining     atomic.Bool        // Whether new seeds are refused until EndDrain
+	drainEnd     chan struct{}      // Closed when the current drain ends
+	drainMu      sync.Mutex         // Protects drainEnd
 	// stopChan   chan struct{}      // Channel to signal when stop is finished
 }
 
@@ -123,6 +127,11 @@ func ReceiveInsert(item *models.Item) error {
 		return ErrReactorNotInitialized
 	}
 
+	if globalReactor.draining.Load() {
+		logger.Debug("received item on draining reactor", "item", item.GetShortID())
+		return ErrReactorDraining
+	}
+
 	select {
 	case <-globalReactor.ctx.Done():
 		logger.Debug("received item on shutting down reactor", "item", item.GetShortID())
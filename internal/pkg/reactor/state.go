@@ -1,6 +1,11 @@
 package reactor
 
-import "github.com/internetarchive/Zeno/pkg/models"
+import (
+	"context"
+	"time"
+
+	"github.com/internetarchive/Zeno/pkg/models"
+)
 
 // GetStateTable returns a slice of all the seeds UUIDs as string in the state table.
 func GetStateTable() []string {
@@ -21,3 +26,51 @@ func GetStateTableItems() []*models.Item {
 	})
 	return items
 }
+
+// waitForHostInterval is how often WaitForHost checks the state table
+var waitForHostInterval = 500 * time.Millisecond
+
+// HostSeedsCount returns the number of seeds of the given host in the state table.
+// It returns 0 if the reactor isn't initialized.
+func HostSeedsCount(host string) int {
+	if globalReactor == nil {
+		return 0
+	}
+
+	count := 0
+	globalReactor.stateTable.Range(func(_, value interface{}) bool {
+		parsed := value.(*models.Item).GetURL().GetParsed()
+		if parsed != nil && parsed.Host == host {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// WaitForHost blocks until there are no more seeds of the given host in the state table.
+// It returns context.DeadlineExceeded if the host still has seeds after timeout.
+func WaitForHost(host string, timeout time.Duration) error {
+	if globalReactor == nil {
+		return ErrReactorNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(globalReactor.ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(waitForHostInterval)
+	defer ticker.Stop()
+
+	for HostSeedsCount(host) > 0 {
+		select {
+		case <-ctx.Done():
+			if globalReactor.ctx.Err() != nil {
+				return ErrReactorShuttingDown
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package reactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func TestWaitForHost(t *testing.T) {
+	outputChan := make(chan *models.Item, 2)
+	if err := Start(2, outputChan); err != nil {
+		t.Fatalf("Error starting reactor: %s", err)
+	}
+	defer Stop()
+
+	waitForHostInterval = 10 * time.Millisecond
+
+	newSeed := func(rawURL string) *models.Item {
+		URL := &models.URL{Raw: rawURL}
+		if err := URL.Parse(); err != nil {
+			t.Fatalf("Error parsing URL: %s", err)
+		}
+		item := models.NewItem(uuid.New().String(), URL, "")
+		item.SetSource(models.ItemSourceInsert)
+		return item
+	}
+
+	seed := newSeed("http://example.com/a")
+	other := newSeed("http://example.org/b")
+	for _, item := range []*models.Item{seed, other} {
+		if err := ReceiveInsert(item); err != nil {
+			t.Fatalf("Error inserting seed: %s", err)
+		}
+	}
+
+	if err := WaitForHost("example.com", 50*time.Millisecond); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// A host without any seed doesn't block
+	if err := WaitForHost("example.net", time.Second); err != nil {
+		t.Fatalf("expected no error for an absent host, got %v", err)
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		time.Sleep(50 * time.Millisecond)
+		MarkAsFinished(seed)
+	}()
+
+	if err := WaitForHost("example.com", time.Second); err != nil {
+		t.Fatalf("expected example.com to be done, got %v", err)
+	}
+	<-finished
+
+	if count := HostSeedsCount("example.org"); count != 1 {
+		t.Fatalf("expected 1 seed left for example.org, got %d", count)
+	}
+}
+
+func TestHostSeedsCountNotInitialized(t *testing.T) {
+	if count := HostSeedsCount("example.com"); count != 0 {
+		t.Fatalf("expected 0 seeds without a reactor, got %d", count)
+	}
+}
@@ -0,0 +1,169 @@
+package results
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"iter"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+)
+
+// HeadersFileName is the name of the response headers archive in the job directory
+const HeadersFileName = "headers.ndjson"
+
+// DefaultHeaderBufferSize is the size of the headers archive write buffer when none is given
+const DefaultHeaderBufferSize = 64 * 1024
+
+// HeaderRecord holds the response headers of a single fetch.
+type HeaderRecord struct {
+	URL        string              `json:"url"`
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers"`
+	Timestamp  time.Time           `json:"timestamp"`
+}
+
+// HeaderArchiver appends the response headers of every fetch as JSON lines to a file.
+type HeaderArchiver struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+var (
+	// ErrHeaderArchiveAlreadyInitialized is returned when the global headers archiver is already started
+	ErrHeaderArchiveAlreadyInitialized = errors.New("headers archiver already initialized")
+
+	globalHeaderArchiver *HeaderArchiver
+	headersLogger        *log.FieldedLogger
+	headersMu            sync.Mutex
+)
+
+// NewHeaderArchiver opens path for appending, buffering up to bufferSize bytes of records.
+func NewHeaderArchiver(path string, bufferSize int) (*HeaderArchiver, error) {
+	if bufferSize <= 0 {
+		bufferSize = DefaultHeaderBufferSize
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HeaderArchiver{
+		file:   file,
+		writer: bufio.NewWriterSize(file, bufferSize),
+	}, nil
+}
+
+// Write appends a record to the archive.
+func (a *HeaderArchiver) Write(record HeaderRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, err := a.writer.Write(line); err != nil {
+		return err
+	}
+
+	return a.writer.WriteByte('\n')
+}
+
+// Flush writes the buffered records to the file.
+func (a *HeaderArchiver) Flush() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.writer.Flush()
+}
+
+// Close flushes the buffered records and closes the file.
+func (a *HeaderArchiver) Close() error {
+	flushErr := a.Flush()
+	return errors.Join(flushErr, a.file.Close())
+}
+
+// StartHeaderArchive opens the global headers archiver writing to path.
+func StartHeaderArchive(path string, bufferSize int) error {
+	headersMu.Lock()
+	defer headersMu.Unlock()
+
+	if globalHeaderArchiver != nil {
+		return ErrHeaderArchiveAlreadyInitialized
+	}
+
+	headersLogger = log.NewFieldedLogger(&log.Fields{
+		"component": "results.headers",
+	})
+
+	archiver, err := NewHeaderArchiver(path, bufferSize)
+	if err != nil {
+		return err
+	}
+
+	globalHeaderArchiver = archiver
+	headersLogger.Info("started", "path", path)
+
+	return nil
+}
+
+// RecordHeaders appends a record to the global headers archiver, it's a no-op if it isn't started.
+func RecordHeaders(record HeaderRecord) {
+	if globalHeaderArchiver == nil {
+		return
+	}
+
+	if err := globalHeaderArchiver.Write(record); err != nil {
+		headersLogger.Error("unable to write headers", "err", err.Error(), "url", record.URL)
+	}
+}
+
+// StopHeaderArchive flushes and closes the global headers archiver.
+func StopHeaderArchive() {
+	headersMu.Lock()
+	defer headersMu.Unlock()
+
+	if globalHeaderArchiver == nil {
+		return
+	}
+
+	if err := globalHeaderArchiver.Close(); err != nil {
+		headersLogger.Error("unable to close headers archive", "err", err.Error())
+	}
+
+	globalHeaderArchiver = nil
+	headersLogger.Info("stopped")
+}
+
+// ReadHeaderArchive streams the records of the headers archive at path.
+// Lines that can't be decoded, like a truncated last line after a crash, are skipped.
+func ReadHeaderArchive(path string) iter.Seq[HeaderRecord] {
+	return func(yield func(HeaderRecord) bool) {
+		file, err := os.Open(path)
+		if err != nil {
+			return
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+		for scanner.Scan() {
+			var record HeaderRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				continue
+			}
+
+			if !yield(record) {
+				return
+			}
+		}
+	}
+}
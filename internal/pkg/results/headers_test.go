@@ -0,0 +1,66 @@
+package results
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHeaderArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), HeadersFileName)
+
+	if err := StartHeaderArchive(path, 16); err != nil {
+		t.Fatalf("unable to start headers archive: %s", err)
+	}
+
+	if err := StartHeaderArchive(path, 16); err != ErrHeaderArchiveAlreadyInitialized {
+		t.Errorf("expected ErrHeaderArchiveAlreadyInitialized, got %v", err)
+	}
+
+	fetchedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	RecordHeaders(HeaderRecord{
+		URL:        "https://example.com/",
+		StatusCode: http.StatusOK,
+		Headers:    http.Header{"Cache-Control": {"max-age=60"}, "Set-Cookie": {"a=1", "b=2"}},
+		Timestamp:  fetchedAt,
+	})
+	RecordHeaders(HeaderRecord{
+		URL:        "https://example.com/missing",
+		StatusCode: http.StatusNotFound,
+		Headers:    http.Header{"X-Custom-Id": {"42"}},
+		Timestamp:  fetchedAt,
+	})
+
+	StopHeaderArchive()
+
+	// A truncated last line is skipped
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("unable to open headers archive: %s", err)
+	}
+	file.WriteString(`{"url":"https://exa`)
+	file.Close()
+
+	var records []HeaderRecord
+	for record := range ReadHeaderArchive(path) {
+		records = append(records, record)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	if records[0].URL != "https://example.com/" || records[0].StatusCode != http.StatusOK || !records[0].Timestamp.Equal(fetchedAt) {
+		t.Errorf("unexpected first record: %+v", records[0])
+	}
+
+	if cookies := records[0].Headers["Set-Cookie"]; len(cookies) != 2 || cookies[1] != "b=2" {
+		t.Errorf("expected both Set-Cookie headers, got %v", cookies)
+	}
+
+	if records[1].StatusCode != http.StatusNotFound || records[1].Headers["X-Custom-Id"][0] != "42" {
+		t.Errorf("unexpected second record: %+v", records[1])
+	}
+}
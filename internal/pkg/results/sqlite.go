@@ -0,0 +1,228 @@
+// Package results records the outcome of every fetch in a SQLite database that can be queried ad hoc.
+package results
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+)
+
+const schema = `CREATE TABLE IF NOT EXISTS crawl_results (
+	url TEXT NOT NULL,
+	status_code INTEGER,
+	content_type TEXT,
+	size INTEGER,
+	hop_depth INTEGER,
+	fetched_at DATETIME,
+	sha256 TEXT,
+	error TEXT
+);`
+
+const insertResult = `INSERT INTO crawl_results (url, status_code, content_type, size, hop_depth, fetched_at, sha256, error) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+// DefaultBatchSize is the number of results inserted per transaction when none is given
+const DefaultBatchSize = 1000
+
+// flushInterval is how often pending results are written, even if the batch isn't full
+var flushInterval = 5 * time.Second
+
+// Result is the outcome of a single fetch.
+type Result struct {
+	URL         string
+	StatusCode  int
+	ContentType string
+	Size        int64
+	HopDepth    int
+	FetchedAt   time.Time
+	SHA256      string // hex-encoded, empty when the body wasn't kept
+	Error       string // empty when the fetch succeeded
+}
+
+// SQLiteResultWriter inserts results in the crawl_results table, batchSize rows per transaction.
+type SQLiteResultWriter struct {
+	db        *sql.DB
+	batchSize int
+	mu        sync.Mutex
+	pending   []Result
+}
+
+var (
+	// ErrResultsAlreadyInitialized is returned when the global results writer is already started
+	ErrResultsAlreadyInitialized = errors.New("results writer already initialized")
+
+	globalWriter *SQLiteResultWriter
+	logger       *log.FieldedLogger
+	once         sync.Once
+	wg           sync.WaitGroup
+	stopCh       chan struct{}
+)
+
+// NewSQLiteResultWriter opens (creating it if needed) the SQLite database at path.
+func NewSQLiteResultWriter(path string, batchSize int) (*SQLiteResultWriter, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+path)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteResultWriter{
+		db:        db,
+		batchSize: batchSize,
+		pending:   make([]Result, 0, batchSize),
+	}, nil
+}
+
+// Write queues a result, the pending results are inserted once the batch is full.
+func (w *SQLiteResultWriter) Write(result Result) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = append(w.pending, result)
+	if len(w.pending) < w.batchSize {
+		return nil
+	}
+
+	return w.flushLocked()
+}
+
+// Flush inserts all the pending results.
+func (w *SQLiteResultWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.flushLocked()
+}
+
+func (w *SQLiteResultWriter) flushLocked() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+
+	tx, err := w.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(insertResult)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, result := range w.pending {
+		_, err := stmt.Exec(result.URL, result.StatusCode, result.ContentType, result.Size, result.HopDepth, result.FetchedAt.UTC().Format(time.RFC3339), result.SHA256, result.Error)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	w.pending = w.pending[:0]
+
+	return nil
+}
+
+// Close flushes the pending results and closes the database.
+func (w *SQLiteResultWriter) Close() error {
+	flushErr := w.Flush()
+	return errors.Join(flushErr, w.db.Close())
+}
+
+// Start opens the global results writer, pending results are flushed every 5 seconds.
+func Start(path string, batchSize int) error {
+	var done bool
+	var err error
+
+	once.Do(func() {
+		logger = log.NewFieldedLogger(&log.Fields{
+			"component": "results",
+		})
+
+		globalWriter, err = NewSQLiteResultWriter(path, batchSize)
+		if err != nil {
+			once = sync.Once{}
+			return
+		}
+
+		stopCh = make(chan struct{})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ticker := time.NewTicker(flushInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-stopCh:
+					return
+				case <-ticker.C:
+					if err := globalWriter.Flush(); err != nil {
+						logger.Error("unable to flush results", "err", err.Error())
+					}
+				}
+			}
+		}()
+
+		logger.Info("started", "path", path)
+		done = true
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !done {
+		return ErrResultsAlreadyInitialized
+	}
+
+	return nil
+}
+
+// Record queues a result in the global results writer, it's a no-op if it isn't started.
+func Record(result Result) {
+	if globalWriter == nil {
+		return
+	}
+
+	if err := globalWriter.Write(result); err != nil {
+		logger.Error("unable to write results", "err", err.Error(), "url", result.URL)
+	}
+}
+
+// Stop flushes the pending results and closes the global results writer.
+func Stop() {
+	if globalWriter == nil {
+		return
+	}
+
+	close(stopCh)
+	wg.Wait()
+
+	if err := globalWriter.Close(); err != nil {
+		logger.Error("unable to close results database", "err", err.Error())
+	}
+
+	globalWriter = nil
+	once = sync.Once{}
+	logger.Info("stopped")
+}
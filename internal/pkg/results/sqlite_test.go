@@ -0,0 +1,68 @@
+package results
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSQLiteResultWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.db")
+
+	w, err := NewSQLiteResultWriter(path, 2)
+	if err != nil {
+		t.Fatalf("unable to create writer: %s", err)
+	}
+
+	countRows := func() (count int) {
+		if err := w.db.QueryRow("SELECT COUNT(*) FROM crawl_results").Scan(&count); err != nil {
+			t.Fatalf("unable to count rows: %s", err)
+		}
+		return count
+	}
+
+	now := time.Now()
+	for _, result := range []Result{
+		{URL: "https://example.com/", StatusCode: 200, ContentType: "text/html", Size: 42, FetchedAt: now, SHA256: "abc"},
+		{URL: "https://example.com/a.png", StatusCode: 404, HopDepth: 1, FetchedAt: now},
+		{URL: "https://example.com/b", HopDepth: 1, FetchedAt: now, Error: "connection refused"},
+	} {
+		if err := w.Write(result); err != nil {
+			t.Fatalf("unable to write result: %s", err)
+		}
+	}
+
+	// Only the first full batch is inserted
+	if count := countRows(); count != 2 {
+		t.Errorf("expected 2 rows before flushing, got %d", count)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatalf("unable to flush: %s", err)
+	}
+
+	if count := countRows(); count != 3 {
+		t.Errorf("expected 3 rows after flushing, got %d", count)
+	}
+
+	var statusCode int
+	var sha string
+	if err := w.db.QueryRow("SELECT status_code, sha256 FROM crawl_results WHERE url = ?", "https://example.com/").Scan(&statusCode, &sha); err != nil {
+		t.Fatalf("unable to query result: %s", err)
+	}
+	if statusCode != 200 || sha != "abc" {
+		t.Errorf("unexpected row: status %d, sha256 %q", statusCode, sha)
+	}
+
+	var fetchErr string
+	if err := w.db.QueryRow("SELECT error FROM crawl_results WHERE url = ?", "https://example.com/b").Scan(&fetchErr); err != nil {
+		t.Fatalf("unable to query failed result: %s", err)
+	}
+	if fetchErr != "connection refused" {
+		t.Errorf("unexpected error for failed fetch: %q", fetchErr)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("unable to close: %s", err)
+	}
+}
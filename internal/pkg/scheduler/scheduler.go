@@ -0,0 +1,239 @@
+// Package scheduler periodically feeds seeds to the reactor according to a cron expression,
+// allowing a crawl job to recrawl the same seeds on a schedule (nightly, weekly...).
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+	"github.com/internetarchive/Zeno/internal/pkg/reactor"
+	"github.com/internetarchive/Zeno/pkg/models"
+	"github.com/robfig/cron/v3"
+)
+
+// OverlapPolicy defines what the scheduler does when a run is triggered while the previous one is still active.
+type OverlapPolicy int
+
+const (
+	// OverlapSkip skips the new run
+	OverlapSkip OverlapPolicy = iota
+	// OverlapQueue runs the new run as soon as the previous one is done, at most one run is queued
+	OverlapQueue
+)
+
+// ParseOverlapPolicy returns the OverlapPolicy named "skip" or "queue".
+func ParseOverlapPolicy(s string) (OverlapPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "skip":
+		return OverlapSkip, nil
+	case "queue":
+		return OverlapQueue, nil
+	default:
+		return OverlapSkip, fmt.Errorf("invalid overlap policy %q: must be skip or queue", s)
+	}
+}
+
+var (
+	// ErrSchedulerAlreadyStarted is returned when Start is called on a running scheduler
+	ErrSchedulerAlreadyStarted = errors.New("scheduler already started")
+)
+
+// CrawlScheduler calls a seed loader at each scheduled time and inserts the seeds in the reactor.
+// A run is active until all of its seeds are finished.
+type CrawlScheduler struct {
+	loader  func() ([]*url.URL, error)
+	spec    string
+	policy  OverlapPolicy
+	cron    *cron.Cron
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	logger  *log.FieldedLogger
+	mu      sync.Mutex
+	running bool     // a run is inserting seeds
+	queued  bool     // a run is waiting for the previous one to finish
+	runIDs  []string // IDs of the seeds inserted by the last run
+
+	// insertFunc and isActiveFunc are used to interact with the reactor, they can be overridden for testing.
+	insertFunc   func(item *models.Item) error
+	isActiveFunc func(IDs []string) bool
+}
+
+// New creates a new CrawlScheduler, the spec is a standard 5-fields cron expression.
+func New(spec string, policy OverlapPolicy, loader func() ([]*url.URL, error)) (*CrawlScheduler, error) {
+	if _, err := cron.ParseStandard(spec); err != nil {
+		return nil, err
+	}
+
+	return &CrawlScheduler{
+		loader: loader,
+		spec:   spec,
+		policy: policy,
+		logger: log.NewFieldedLogger(&log.Fields{
+			"component": "scheduler",
+		}),
+		insertFunc:   reactor.ReceiveInsert,
+		isActiveFunc: seedsInReactor,
+	}, nil
+}
+
+// Start starts the scheduler, runs are triggered in their own goroutine.
+func (s *CrawlScheduler) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cron != nil {
+		return ErrSchedulerAlreadyStarted
+	}
+
+	s.cron = cron.New()
+	if _, err := s.cron.AddFunc(s.spec, s.trigger); err != nil {
+		s.cron = nil
+		return err
+	}
+
+	s.cron.Start()
+
+	// Watch for the end of the active run to start the queued one, if any
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runQueued()
+			}
+		}
+	}()
+
+	s.logger.Info("started", "spec", s.spec)
+
+	return nil
+}
+
+// Stop stops the scheduler and waits for the ongoing run, if any, to finish inserting its seeds.
+func (s *CrawlScheduler) Stop() {
+	s.mu.Lock()
+	c, cancel := s.cron, s.cancel
+	s.cron, s.cancel = nil, nil
+	s.mu.Unlock()
+
+	if c != nil {
+		cancel()
+		s.wg.Wait()
+		<-c.Stop().Done()
+		s.logger.Info("stopped")
+	}
+}
+
+// IsActive returns true if the last run is still inserting seeds or if any of its seeds is still being crawled.
+func (s *CrawlScheduler) IsActive() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.isActiveLocked()
+}
+
+func (s *CrawlScheduler) isActiveLocked() bool {
+	return s.running || (len(s.runIDs) > 0 && s.isActiveFunc(s.runIDs))
+}
+
+// trigger is called by cron at each scheduled time.
+func (s *CrawlScheduler) trigger() {
+	s.mu.Lock()
+	if s.isActiveLocked() {
+		if s.policy == OverlapSkip || s.queued {
+			s.mu.Unlock()
+			s.logger.Info("previous run still active, skipping")
+			return
+		}
+
+		s.queued = true
+		s.mu.Unlock()
+		s.logger.Info("previous run still active, queuing")
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.run()
+}
+
+// run loads and inserts the seeds, then runs the queued run if any once the current one is done.
+func (s *CrawlScheduler) run() {
+	var IDs []string
+
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.runIDs = IDs
+		s.mu.Unlock()
+	}()
+
+	seeds, err := s.loader()
+	if err != nil {
+		s.logger.Error("unable to load seeds", "err", err.Error())
+		return
+	}
+
+	for _, seed := range seeds {
+		item := models.NewItem(uuid.New().String(), &models.URL{Raw: seed.String()}, "")
+		if err := item.GetURL().Parse(); err != nil {
+			s.logger.Warn("unable to parse seed", "url", seed.String(), "err", err.Error())
+			continue
+		}
+
+		if err := s.insertFunc(item); err != nil {
+			s.logger.Error("unable to insert seed", "url", seed.String(), "err", err.Error())
+			return
+		}
+
+		IDs = append(IDs, item.GetID())
+	}
+
+	s.logger.Info("run done", "seeds", len(IDs))
+}
+
+// runQueued starts the queued run if any and if the previous run is done, it returns true if a run was started.
+func (s *CrawlScheduler) runQueued() bool {
+	s.mu.Lock()
+	if !s.queued || s.isActiveLocked() {
+		s.mu.Unlock()
+		return false
+	}
+	s.queued = false
+	s.running = true
+	s.mu.Unlock()
+
+	s.run()
+	return true
+}
+
+func seedsInReactor(IDs []string) bool {
+	active := make(map[string]struct{})
+	for _, ID := range reactor.GetStateTable() {
+		active[ID] = struct{}{}
+	}
+
+	for _, ID := range IDs {
+		if _, ok := active[ID]; ok {
+			return true
+		}
+	}
+
+	return false
+}
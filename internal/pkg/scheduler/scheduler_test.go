@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/internetarchive/Zeno/pkg/models"
+)
+
+func newTestScheduler(t *testing.T, policy OverlapPolicy, active *bool, inserted *int) *CrawlScheduler {
+	t.Helper()
+
+	loader := func() ([]*url.URL, error) {
+		u, _ := url.Parse("https://example.com/")
+		return []*url.URL{u}, nil
+	}
+
+	s, err := New("@daily", policy, loader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.insertFunc = func(_ *models.Item) error {
+		*inserted++
+		return nil
+	}
+	s.isActiveFunc = func(_ []string) bool { return *active }
+
+	return s
+}
+
+func TestNewInvalidSpec(t *testing.T) {
+	if _, err := New("not a cron", OverlapSkip, nil); err == nil {
+		t.Fatal("expected an error for an invalid cron expression")
+	}
+}
+
+func TestOverlapSkip(t *testing.T) {
+	var active bool
+	var inserted int
+	s := newTestScheduler(t, OverlapSkip, &active, &inserted)
+
+	s.trigger()
+	if inserted != 1 {
+		t.Fatalf("expected 1 inserted seed, got %d", inserted)
+	}
+
+	active = true
+	s.trigger()
+	active = false
+	if s.runQueued() || inserted != 1 {
+		t.Fatalf("expected the overlapping run to be skipped, got %d inserted seeds", inserted)
+	}
+}
+
+func TestOverlapQueue(t *testing.T) {
+	var active bool
+	var inserted int
+	s := newTestScheduler(t, OverlapQueue, &active, &inserted)
+
+	s.trigger()
+
+	active = true
+	s.trigger()
+	s.trigger()
+	if s.runQueued() || inserted != 1 {
+		t.Fatalf("expected the queued run to wait for the previous one, got %d inserted seeds", inserted)
+	}
+
+	active = false
+	if !s.runQueued() || inserted != 2 {
+		t.Fatalf("expected exactly one queued run to be started, got %d inserted seeds", inserted)
+	}
+
+	if s.runQueued() {
+		t.Fatal("expected no more queued run")
+	}
+}
+
+func TestSeedFromReader(t *testing.T) {
+	input := "https://example.com/\n\n# comment\nnot a url\nhttp://example.org/page\n"
+
+	seeds, err := SeedFromReader(strings.NewReader(input))()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(seeds) != 2 || seeds[0].String() != "https://example.com/" || seeds[1].String() != "http://example.org/page" {
+		t.Errorf("unexpected seeds: %v", seeds)
+	}
+}
+
+func TestParseOverlapPolicy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    OverlapPolicy
+		wantErr bool
+	}{
+		{"skip", OverlapSkip, false},
+		{"Queue", OverlapQueue, false},
+		{"", OverlapSkip, true},
+		{"wait", OverlapSkip, true},
+	}
+
+	for _, tt := range tests {
+		policy, err := ParseOverlapPolicy(tt.value)
+		if (err != nil) != tt.wantErr || policy != tt.want {
+			t.Errorf("ParseOverlapPolicy(%q) = %v, %v, want %v (error: %v)", tt.value, policy, err, tt.want, tt.wantErr)
+		}
+	}
+}
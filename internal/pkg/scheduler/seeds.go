@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"bufio"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+)
+
+// SeedFromReader returns a seed loader reading newline-delimited URLs from the reader.
+// Empty lines and lines starting with # are ignored, invalid URLs are logged and skipped.
+func SeedFromReader(r io.Reader) func() ([]*url.URL, error) {
+	return func() ([]*url.URL, error) {
+		logger := log.NewFieldedLogger(&log.Fields{
+			"component": "scheduler.SeedFromReader",
+		})
+
+		var seeds []*url.URL
+
+		scanner := bufio.NewScanner(r)
+		for lineNumber := 1; scanner.Scan(); lineNumber++ {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			seed, err := url.ParseRequestURI(line)
+			if err != nil || seed.Host == "" {
+				logger.Warn("skipping invalid seed", "line", lineNumber, "value", line)
+				continue
+			}
+
+			seeds = append(seeds, seed)
+		}
+
+		return seeds, scanner.Err()
+	}
+}
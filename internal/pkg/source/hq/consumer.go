@@ -159,8 +159,9 @@ func consumerSender(ctx context.Context, wg *sync.WaitGroup, urlBuffer <-chan *g
 			var discard bool
 			// Process the URL and create a new Item
 			parsedURL := models.URL{
-				Raw:  URL.Value,
-				Hops: pathToHops(URL.Path),
+				Raw:             URL.Value,
+				Hops:            pathToHops(URL.Path),
+				PaginationDepth: pathToPaginationDepth(URL.Path),
 			}
 			err := parsedURL.Parse()
 			if err != nil {
@@ -180,6 +181,14 @@ func consumerSender(ctx context.Context, wg *sync.WaitGroup, urlBuffer <-chan *g
 
 			// Send the new Item to the reactor
 			err = reactor.ReceiveInsert(newItem)
+			for err == reactor.ErrReactorDraining {
+				// Hold the item until the reactor accepts new seeds again
+				if reactor.WaitDrainEnd(ctx) != nil {
+					logger.Debug("closed while waiting for the reactor to end draining")
+					return
+				}
+				err = reactor.ReceiveInsert(newItem)
+			}
 			if err != nil {
 				if err == reactor.ErrReactorFrozen {
 					select {
@@ -89,7 +89,7 @@ func producerReceiver(ctx context.Context, wg *sync.WaitGroup, batchCh chan *pro
 			URL := gocrawlhq.URL{
 				Value: item.GetURL().Raw,
 				Via:   item.GetSeedVia(),
-				Path:  hopsToPath(item.GetURL().GetHops()),
+				Path:  hopsToPath(item.GetURL().GetHops()) + paginationDepthToPath(item.GetURL().PaginationDepth),
 			}
 			batch.URLs = append(batch.URLs, URL)
 			if len(batch.URLs) >= batchSize {
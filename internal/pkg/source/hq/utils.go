@@ -13,3 +13,14 @@ func hopsToPath(hops int) (path string) {
 	// For each hop, add an L to the path
 	return strings.Repeat("L", hops)
 }
+
+// pathToPaginationDepth returns the position of the URL in its chain of rel="next" pages,
+// stored as N hops in the path as next pages keep the hop count of the page they're found on
+func pathToPaginationDepth(path string) (depth int) {
+	return strings.Count(path, "N")
+}
+
+// paginationDepthToPath returns the N hops added to the path of a next page
+func paginationDepthToPath(depth int) (path string) {
+	return strings.Repeat("N", depth)
+}
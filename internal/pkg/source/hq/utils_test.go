@@ -24,3 +24,15 @@ func TestPathToHop(t *testing.T) {
 		}
 	}
 }
+
+func TestPaginationDepthPath(t *testing.T) {
+	path := hopsToPath(2) + paginationDepthToPath(3)
+
+	if hops := pathToHops(path); hops != 2 {
+		t.Errorf("For path %q, expected 2 hops, but got %d", path, hops)
+	}
+
+	if depth := pathToPaginationDepth(path); depth != 3 {
+		t.Errorf("For path %q, expected a pagination depth of 3, but got %d", path, depth)
+	}
+}
@@ -34,6 +34,11 @@ func Init(job string) (*LQClient, error) {
 		return nil, err
 	}
 
+	if err := migrate(dbWrite); err != nil {
+		logger.Error("error migrating lq database schema", "err", err.Error(), "func", "lq.Init")
+		return nil, err
+	}
+
 	dbWriteSqlc := sqlc_model.New(dbWrite)
 
 	return &LQClient{
@@ -42,6 +47,10 @@ func Init(job string) (*LQClient, error) {
 	}, nil
 }
 
+func (c *LQClient) CountFresh(ctx context.Context) (int64, error) {
+	return c.dbWriteSqlc.CountFreshURLs(ctx)
+}
+
 func (c *LQClient) ResetURL(ctx context.Context, seed string) error {
 	return c.dbWriteSqlc.ResetURL(ctx, seed)
 }
@@ -88,10 +97,12 @@ func (c *LQClient) Add(ctx context.Context, urls []sqlc_model.Url, bypassSeenche
 			url.ID = uuid.New().String()
 		}
 		err = qtx.AddURL(ctx, sqlc_model.AddURLParams{
-			ID:    url.ID,
-			Value: url.Value,
-			Via:   url.Via,
-			Hops:  int64(url.Hops),
+			ID:              url.ID,
+			Value:           url.Value,
+			Via:             url.Via,
+			Hops:            int64(url.Hops),
+			Priority:        url.Priority,
+			PaginationDepth: url.PaginationDepth,
 		})
 		if err != nil {
 			if err.Error() == "sqlite3: constraint failed: UNIQUE constraint failed: urls.value" {
@@ -81,6 +81,7 @@ func consumerFetcher(ctx context.Context, wg *sync.WaitGroup, urlBuffer chan<- *
 
 		// Fetch URLs from LQ
 		URLs, err := getURLs(batchSize)
+		feedEmpty.Store(err == nil && len(URLs) == 0)
 		if err != nil || len(URLs) == 0 {
 			if err != nil {
 				logger.Error("error fetching URLs from LQ", "err", err.Error(), "func", "lq.consumerFetcher")
@@ -104,12 +105,14 @@ func consumerFetcher(ctx context.Context, wg *sync.WaitGroup, urlBuffer chan<- *
 				logger.Debug("closed")
 				return
 			case urlBuffer <- &sqlc_model.Url{
-				ID:        URLs[i].ID,
-				Value:     URLs[i].Value,
-				Via:       URLs[i].Via,
-				Hops:      URLs[i].Hops,
-				Status:    URLs[i].Status,
-				Timestamp: URLs[i].Timestamp,
+				ID:              URLs[i].ID,
+				Value:           URLs[i].Value,
+				Via:             URLs[i].Via,
+				Hops:            URLs[i].Hops,
+				Status:          URLs[i].Status,
+				Timestamp:       URLs[i].Timestamp,
+				Priority:        URLs[i].Priority,
+				PaginationDepth: URLs[i].PaginationDepth,
 			}: //Deep copy of the URL to ensure pointer alisaing does not cause issues
 			}
 		}
@@ -146,8 +149,10 @@ func consumerSender(ctx context.Context, wg *sync.WaitGroup, urlBuffer <-chan *s
 			var discard bool
 			// Process the URL and create a new Item
 			parsedURL := models.URL{
-				Raw:  URL.Value,
-				Hops: int(URL.Hops),
+				Raw:             URL.Value,
+				Hops:            int(URL.Hops),
+				Priority:        URL.Priority,
+				PaginationDepth: int(URL.PaginationDepth),
 			}
 			err := parsedURL.Parse()
 			if err != nil {
@@ -167,6 +172,14 @@ func consumerSender(ctx context.Context, wg *sync.WaitGroup, urlBuffer <-chan *s
 
 			// Send the new Item to the reactor
 			err = reactor.ReceiveInsert(newItem)
+			for err == reactor.ErrReactorDraining {
+				// Hold the item until the reactor accepts new seeds again
+				if reactor.WaitDrainEnd(ctx) != nil {
+					logger.Debug("closed while waiting for the reactor to end draining")
+					return
+				}
+				err = reactor.ReceiveInsert(newItem)
+			}
 			if err != nil {
 				if err == reactor.ErrReactorFrozen {
 					select {
@@ -5,4 +5,6 @@ import "errors"
 var (
 	//  is the error returned when the postprocessor is already initialized
 	ErrLQAlreadyInitialized = errors.New("lq client already initialized")
+	// ErrLQNotInitialized is the error returned when the local queue is used before being started
+	ErrLQNotInitialized = errors.New("lq client not initialized")
 )
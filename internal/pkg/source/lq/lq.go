@@ -3,6 +3,7 @@ package lq
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"github.com/internetarchive/Zeno/internal/pkg/config"
 	"github.com/internetarchive/Zeno/internal/pkg/log"
@@ -24,8 +25,25 @@ var (
 	globalLQ *lq
 	once     sync.Once
 	logger   *log.FieldedLogger
+
+	// feedEmpty is set by the consumer when the last fetch from the queue returned no URL
+	feedEmpty atomic.Bool
 )
 
+// IsEmpty returns true if the last attempt to fetch URLs from the local queue returned nothing.
+func IsEmpty() bool {
+	return feedEmpty.Load()
+}
+
+// RemainingURLs returns the number of URLs of the local queue that weren't claimed yet.
+func RemainingURLs() (int64, error) {
+	if globalLQ == nil {
+		return 0, ErrLQNotInitialized
+	}
+
+	return globalLQ.client.CountFresh(context.Background())
+}
+
 func Start(finishChan, produceChan chan *models.Item) error {
 	var done bool
 	var startErr error
@@ -0,0 +1,33 @@
+package lq
+
+import "database/sql"
+
+// addedColumns are the columns added to the urls table after its creation, with their definition
+var addedColumns = []struct{ name, definition string }{
+	{"priority", "REAL NOT NULL DEFAULT 0"},
+	{"pagination_depth", "INTEGER NOT NULL DEFAULT 0"},
+}
+
+// migrate brings queues created by older versions of Zeno to the current schema.
+// CREATE TABLE IF NOT EXISTS leaves existing tables untouched, so new columns are added here.
+func migrate(db *sql.DB) error {
+	for _, column := range addedColumns {
+		var exists bool
+		err := db.QueryRow(`SELECT COUNT(*) > 0 FROM pragma_table_info('urls') WHERE name = ?`, column.name).Scan(&exists)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			continue
+		}
+
+		if _, err := db.Exec(`ALTER TABLE urls ADD COLUMN ` + column.name + ` ` + column.definition); err != nil {
+			return err
+		}
+	}
+
+	// The fresh URLs are claimed by hops then priority
+	_, err := db.Exec(`CREATE INDEX IF NOT EXISTS urls_priority ON urls (status, hops, priority DESC)`)
+	return err
+}
@@ -0,0 +1,79 @@
+package lq
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/internetarchive/Zeno/internal/pkg/source/lq/sqlc_model"
+)
+
+// oldSchema is the schema of the queues created before URLs had a priority
+const oldSchema = `CREATE TABLE urls (
+    id TEXT NOT NULL PRIMARY KEY,
+    value TEXT NOT NULL,
+    via TEXT DEFAULT '' NOT NULL,
+    hops INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'FRESH' CHECK (status IN ('FRESH', 'CLAIMED', 'DONE')),
+    timestamp INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+INSERT INTO urls (id, value, hops) VALUES ('old', 'https://example.com/old', 1);`
+
+func TestMigrateAndPriorityOrder(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "lq.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(oldSchema); err != nil {
+		t.Fatalf("unable to create the old schema: %s", err)
+	}
+
+	// The current schema and the migration must both apply to an old queue, twice
+	for range 2 {
+		if _, err := db.Exec(ddl); err != nil {
+			t.Fatalf("unable to apply the schema: %s", err)
+		}
+
+		if err := migrate(db); err != nil {
+			t.Fatalf("unable to migrate: %s", err)
+		}
+	}
+
+	ctx := context.Background()
+	queries := sqlc_model.New(db)
+
+	for _, params := range []sqlc_model.AddURLParams{
+		{ID: "low", Value: "https://example.com/low", Hops: 1, Priority: 1.2},
+		{ID: "seed", Value: "https://example.com/", Hops: 0},
+		{ID: "high", Value: "https://example.com/high", Hops: 1, Priority: 3.5, PaginationDepth: 2},
+	} {
+		if err := queries.AddURL(ctx, params); err != nil {
+			t.Fatalf("unable to add %s: %s", params.ID, err)
+		}
+	}
+
+	fresh, err := queries.GetFreshURLs(ctx, 10)
+	if err != nil {
+		t.Fatalf("unable to get fresh URLs: %s", err)
+	}
+
+	// Lower hops first, then the highest priority, URLs queued before the migration having none
+	expected := []string{"seed", "high", "low", "old"}
+	if len(fresh) != len(expected) {
+		t.Fatalf("expected %d fresh URLs, got %d", len(expected), len(fresh))
+	}
+
+	for i := range expected {
+		if fresh[i].ID != expected[i] {
+			t.Errorf("expected %s at position %d, got %s", expected[i], i, fresh[i].ID)
+		}
+	}
+
+	if fresh[1].Priority != 3.5 || fresh[1].PaginationDepth != 2 {
+		t.Errorf("expected the priority and pagination depth to be stored, got %f and %d", fresh[1].Priority, fresh[1].PaginationDepth)
+	}
+}
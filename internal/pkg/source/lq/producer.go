@@ -86,9 +86,11 @@ func producerReceiver(ctx context.Context, wg *sync.WaitGroup, batchCh chan *pro
 			return
 		case item := <-globalLQ.produceCh:
 			URL := sqlc_model.Url{
-				Value: item.GetURL().Raw,
-				Via:   item.GetSeedVia(),
-				Hops:  int64(item.GetURL().GetHops()),
+				Value:           item.GetURL().Raw,
+				Via:             item.GetSeedVia(),
+				Hops:            int64(item.GetURL().GetHops()),
+				Priority:        item.GetURL().Priority,
+				PaginationDepth: int64(item.GetURL().PaginationDepth),
 			}
 			batch.URLs = append(batch.URLs, URL)
 			if len(batch.URLs) >= batchSize {
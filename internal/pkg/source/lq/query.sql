@@ -1,6 +1,7 @@
 -- name: GetFreshURLs :many
 SELECT * FROM urls
 WHERE status = 'FRESH'
+ORDER BY hops, priority DESC
 LIMIT ?;
 
 -- name: ClaimThisURL :exec
@@ -8,14 +9,18 @@ UPDATE urls
 SET status = 'CLAIMED', timestamp = strftime('%s', 'now')
 WHERE id = ?;
 
+-- name: CountFreshURLs :one
+SELECT COUNT(*) FROM urls
+WHERE status = 'FRESH';
+
 -- name: ResetURL :exec
 UPDATE urls
 SET status = 'FRESH', timestamp = strftime('%s', 'now')
 WHERE id = ?;
 
 -- name: AddURL :exec
-INSERT INTO urls (id, value, via, hops)
-VALUES (?, ?, ?, ?);
+INSERT INTO urls (id, value, via, hops, priority, pagination_depth)
+VALUES (?, ?, ?, ?, ?, ?);
 
 -- name: DoneURL :exec
 UPDATE urls
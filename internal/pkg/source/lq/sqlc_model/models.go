@@ -5,10 +5,12 @@
 package sqlc_model
 
 type Url struct {
-	ID        string
-	Value     string
-	Via       string
-	Hops      int64
-	Status    string
-	Timestamp int64
+	ID              string
+	Value           string
+	Via             string
+	Hops            int64
+	Status          string
+	Timestamp       int64
+	Priority        float64
+	PaginationDepth int64
 }
@@ -10,15 +10,17 @@ import (
 )
 
 const addURL = `-- name: AddURL :exec
-INSERT INTO urls (id, value, via, hops)
-VALUES (?, ?, ?, ?)
+INSERT INTO urls (id, value, via, hops, priority, pagination_depth)
+VALUES (?, ?, ?, ?, ?, ?)
 `
 
 type AddURLParams struct {
-	ID    string
-	Value string
-	Via   string
-	Hops  int64
+	ID              string
+	Value           string
+	Via             string
+	Hops            int64
+	Priority        float64
+	PaginationDepth int64
 }
 
 func (q *Queries) AddURL(ctx context.Context, arg AddURLParams) error {
@@ -27,6 +29,8 @@ func (q *Queries) AddURL(ctx context.Context, arg AddURLParams) error {
 		arg.Value,
 		arg.Via,
 		arg.Hops,
+		arg.Priority,
+		arg.PaginationDepth,
 	)
 	return err
 }
@@ -42,6 +46,18 @@ func (q *Queries) ClaimThisURL(ctx context.Context, id string) error {
 	return err
 }
 
+const countFreshURLs = `-- name: CountFreshURLs :one
+SELECT COUNT(*) FROM urls
+WHERE status = 'FRESH'
+`
+
+func (q *Queries) CountFreshURLs(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countFreshURLs)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteURL = `-- name: DeleteURL :exec
 DELETE FROM urls
 WHERE id = ?
@@ -64,8 +80,9 @@ func (q *Queries) DoneURL(ctx context.Context, id string) error {
 }
 
 const getFreshURLs = `-- name: GetFreshURLs :many
-SELECT id, value, via, hops, status, timestamp FROM urls
+SELECT id, value, via, hops, status, timestamp, priority, pagination_depth FROM urls
 WHERE status = 'FRESH'
+ORDER BY hops, priority DESC
 LIMIT ?
 `
 
@@ -85,6 +102,8 @@ func (q *Queries) GetFreshURLs(ctx context.Context, limit int64) ([]Url, error)
 			&i.Hops,
 			&i.Status,
 			&i.Timestamp,
+			&i.Priority,
+			&i.PaginationDepth,
 		); err != nil {
 			return nil, err
 		}
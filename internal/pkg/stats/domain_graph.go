@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DomainEdge is a directed host-to-host link relationship, weighted by the number of links seen.
+type DomainEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Weight int64  `json:"weight"`
+}
+
+// hostEdges holds the outgoing edges of a single source host.
+type hostEdges struct {
+	sync.Mutex
+	targets map[string]int64
+}
+
+// DomainGraphBuilder aggregates the links between hosts into a directed, weighted graph.
+type DomainGraphBuilder struct {
+	edges sync.Map // source host -> *hostEdges
+}
+
+// NewDomainGraphBuilder returns an empty domain graph.
+func NewDomainGraphBuilder() *DomainGraphBuilder {
+	return &DomainGraphBuilder{}
+}
+
+// AddEdge records a link from the source host to the target host.
+func (g *DomainGraphBuilder) AddEdge(source, target string) {
+	value, _ := g.edges.LoadOrStore(source, &hostEdges{targets: make(map[string]int64)})
+	edges := value.(*hostEdges)
+
+	edges.Lock()
+	edges.targets[target]++
+	edges.Unlock()
+}
+
+// Edges returns all the edges of the graph, heaviest first.
+func (g *DomainGraphBuilder) Edges() []DomainEdge {
+	var result []DomainEdge
+
+	g.edges.Range(func(key, value any) bool {
+		edges := value.(*hostEdges)
+
+		edges.Lock()
+		for target, weight := range edges.targets {
+			result = append(result, DomainEdge{Source: key.(string), Target: target, Weight: weight})
+		}
+		edges.Unlock()
+
+		return true
+	})
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Weight != result[j].Weight {
+			return result[i].Weight > result[j].Weight
+		}
+		if result[i].Source != result[j].Source {
+			return result[i].Source < result[j].Source
+		}
+		return result[i].Target < result[j].Target
+	})
+
+	return result
+}
+
+// Top returns the n heaviest edges of the graph.
+func (g *DomainGraphBuilder) Top(n int) []DomainEdge {
+	edges := g.Edges()
+	if len(edges) > n {
+		edges = edges[:n]
+	}
+
+	return edges
+}
+
+// WriteCSV writes the graph as an edge list with a source,target,weight header.
+func (g *DomainGraphBuilder) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"source", "target", "weight"}); err != nil {
+		return err
+	}
+
+	for _, edge := range g.Edges() {
+		if err := writer.Write([]string{edge.Source, edge.Target, strconv.FormatInt(edge.Weight, 10)}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Reset removes all the edges of the graph.
+func (g *DomainGraphBuilder) Reset() {
+	g.edges.Clear()
+}
@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+)
+
+var (
+	domainGraphCtx, domainGraphCancel = context.WithCancel(context.Background())
+	domainGraphWg                     sync.WaitGroup
+)
+
+// defaultDomainGraphInterval is used when the given interval isn't positive
+const defaultDomainGraphInterval = 5 * time.Minute
+
+// StartDomainGraphWriter writes the domain graph to the given file every interval, as a CSV edge list.
+// The file is replaced at each write and once more when the writer stops.
+func StartDomainGraphWriter(path string, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultDomainGraphInterval
+	}
+
+	domainGraphWg.Add(1)
+	go func() {
+		defer domainGraphWg.Done()
+
+		logger := log.NewFieldedLogger(&log.Fields{
+			"component": "stats.domainGraphWriter",
+		})
+		defer logger.Debug("closed")
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-domainGraphCtx.Done():
+				if err := WriteDomainGraph(path); err != nil {
+					logger.Error("unable to write domain graph", "err", err.Error(), "path", path)
+				}
+				return
+			case <-ticker.C:
+				if err := WriteDomainGraph(path); err != nil {
+					logger.Error("unable to write domain graph", "err", err.Error(), "path", path)
+				}
+			}
+		}
+	}()
+}
+
+// StopDomainGraphWriter stops the domain graph writer after a last write.
+func StopDomainGraphWriter() {
+	domainGraphCancel()
+	domainGraphWg.Wait()
+}
+
+// WriteDomainGraph writes the current domain graph to the given file.
+func WriteDomainGraph(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	if err := globalStats.DomainGraph.WriteCSV(writer); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
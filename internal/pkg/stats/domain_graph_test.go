@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDomainGraphBuilder(t *testing.T) {
+	graph := NewDomainGraphBuilder()
+
+	for range 3 {
+		graph.AddEdge("example.com", "archive.org")
+	}
+	graph.AddEdge("example.com", "example.net")
+	graph.AddEdge("example.net", "example.com")
+	graph.AddEdge("example.net", "example.com")
+
+	top := graph.Top(2)
+	expected := []DomainEdge{
+		{Source: "example.com", Target: "archive.org", Weight: 3},
+		{Source: "example.net", Target: "example.com", Weight: 2},
+	}
+	if len(top) != len(expected) {
+		t.Fatalf("expected %d edges, got %d", len(expected), len(top))
+	}
+	for i := range expected {
+		if top[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], top[i])
+		}
+	}
+
+	if edges := graph.Edges(); len(edges) != 3 {
+		t.Errorf("expected 3 edges, got %d", len(edges))
+	}
+
+	graph.Reset()
+	if edges := graph.Edges(); len(edges) != 0 {
+		t.Errorf("expected no edges after reset, got %d", len(edges))
+	}
+}
+
+func TestWriteDomainGraph(t *testing.T) {
+	if err := Init(); err != nil {
+		t.Fatalf("unable to init stats: %s", err)
+	}
+	DomainGraphReset()
+	defer DomainGraphReset()
+
+	DomainGraphAddEdge("example.com", "archive.org")
+	DomainGraphAddEdge("example.com", "archive.org")
+	DomainGraphAddEdge("archive.org", "example.com")
+
+	path := filepath.Join(t.TempDir(), "graph.csv")
+	if err := WriteDomainGraph(path); err != nil {
+		t.Fatalf("unable to write domain graph: %s", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read domain graph: %s", err)
+	}
+
+	expected := "source,target,weight\nexample.com,archive.org,2\narchive.org,example.com,1\n"
+	if string(content) != expected {
+		t.Errorf("expected %q, got %q", expected, string(content))
+	}
+}
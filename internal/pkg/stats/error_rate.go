@@ -0,0 +1,69 @@
+package stats
+
+import "sync"
+
+// errorWindow is a sliding window over the outcome of the last fetches.
+type errorWindow struct {
+	sync.Mutex
+	outcomes []bool // true if the fetch failed
+	next     int
+	filled   bool
+	failures int
+}
+
+func newErrorWindow(size int) *errorWindow {
+	if size <= 0 {
+		size = 1
+	}
+
+	return &errorWindow{
+		outcomes: make([]bool, size),
+	}
+}
+
+func (w *errorWindow) add(failed bool) {
+	w.Lock()
+	defer w.Unlock()
+
+	// Evict the oldest outcome if the window is full
+	if w.filled && w.outcomes[w.next] {
+		w.failures--
+	}
+
+	w.outcomes[w.next] = failed
+	if failed {
+		w.failures++
+	}
+
+	w.next = (w.next + 1) % len(w.outcomes)
+	if w.next == 0 {
+		w.filled = true
+	}
+}
+
+// rate returns the fraction of failed fetches in the window and the number of fetches in it.
+func (w *errorWindow) rate() (float64, int) {
+	w.Lock()
+	defer w.Unlock()
+
+	samples := w.next
+	if w.filled {
+		samples = len(w.outcomes)
+	}
+
+	if samples == 0 {
+		return 0, 0
+	}
+
+	return float64(w.failures) / float64(samples), samples
+}
+
+func (w *errorWindow) reset() {
+	w.Lock()
+	defer w.Unlock()
+
+	for i := range w.outcomes {
+		w.outcomes[i] = false
+	}
+	w.next, w.filled, w.failures = 0, false, 0
+}
@@ -0,0 +1,36 @@
+package stats
+
+import "testing"
+
+func TestErrorWindow(t *testing.T) {
+	w := newErrorWindow(4)
+
+	if rate, samples := w.rate(); rate != 0 || samples != 0 {
+		t.Fatalf("expected empty window, got rate %f with %d samples", rate, samples)
+	}
+
+	w.add(true)
+	w.add(false)
+	if rate, samples := w.rate(); rate != 0.5 || samples != 2 {
+		t.Fatalf("expected rate 0.5 with 2 samples, got %f with %d", rate, samples)
+	}
+
+	// Fill the window then evict the first failure
+	w.add(false)
+	w.add(false)
+	w.add(false)
+	if rate, samples := w.rate(); rate != 0 || samples != 4 {
+		t.Fatalf("expected rate 0 with 4 samples, got %f with %d", rate, samples)
+	}
+
+	w.add(true)
+	w.add(true)
+	if rate, _ := w.rate(); rate != 0.5 {
+		t.Fatalf("expected rate 0.5, got %f", rate)
+	}
+
+	w.reset()
+	if rate, samples := w.rate(); rate != 0 || samples != 0 {
+		t.Fatalf("expected reset window, got rate %f with %d samples", rate, samples)
+	}
+}
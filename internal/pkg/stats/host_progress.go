@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// HostProgressEntry is the crawl progress of a single host.
+type HostProgressEntry struct {
+	Host             string    `json:"host"`
+	Queued           uint64    `json:"queued"`
+	Fetched          uint64    `json:"fetched"`
+	Failed           uint64    `json:"failed"`
+	BytesArchived    uint64    `json:"bytes_archived"`
+	LastActivityTime time.Time `json:"last_activity_time"`
+}
+
+type hostProgress struct {
+	sync.Mutex
+	data map[string]*HostProgressEntry
+}
+
+func newHostProgress() *hostProgress {
+	return &hostProgress{
+		data: make(map[string]*HostProgressEntry),
+	}
+}
+
+// update applies fn to the entry of the host, creating it if needed, and refreshes its last activity time.
+func (hp *hostProgress) update(host string, fn func(entry *HostProgressEntry)) {
+	hp.Lock()
+	defer hp.Unlock()
+
+	entry, ok := hp.data[host]
+	if !ok {
+		entry = &HostProgressEntry{Host: host}
+		hp.data[host] = entry
+	}
+
+	fn(entry)
+	entry.LastActivityTime = time.Now()
+}
+
+// snapshot returns a copy of all the entries, sorted by host.
+func (hp *hostProgress) snapshot() []HostProgressEntry {
+	hp.Lock()
+	defer hp.Unlock()
+
+	entries := make([]HostProgressEntry, 0, len(hp.data))
+	for _, entry := range hp.data {
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Host < entries[j].Host
+	})
+
+	return entries
+}
+
+func (hp *hostProgress) reset() {
+	hp.Lock()
+	defer hp.Unlock()
+
+	hp.data = make(map[string]*HostProgressEntry)
+}
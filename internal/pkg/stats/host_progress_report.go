@@ -0,0 +1,106 @@
+package stats
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/internetarchive/Zeno/internal/pkg/log"
+)
+
+var (
+	hostReportCtx, hostReportCancel = context.WithCancel(context.Background())
+	hostReportWg                    sync.WaitGroup
+)
+
+// StartHostProgressReporter writes the per-host crawl progress to the given file every interval,
+// as one JSON line per host. The file is replaced at each write and once more when the reporter stops.
+func StartHostProgressReporter(path string, interval time.Duration) {
+	hostReportWg.Add(1)
+	go func() {
+		defer hostReportWg.Done()
+
+		logger := log.NewFieldedLogger(&log.Fields{
+			"component": "stats.hostProgressReporter",
+		})
+		defer logger.Debug("closed")
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-hostReportCtx.Done():
+				if err := WriteHostProgressReport(path); err != nil {
+					logger.Error("unable to write host progress report", "err", err.Error(), "path", path)
+				}
+				return
+			case <-ticker.C:
+				if err := WriteHostProgressReport(path); err != nil {
+					logger.Error("unable to write host progress report", "err", err.Error(), "path", path)
+				}
+			}
+		}
+	}()
+}
+
+// StopHostProgressReporter stops the host progress reporter after a last write.
+func StopHostProgressReporter() {
+	hostReportCancel()
+	hostReportWg.Wait()
+}
+
+// WriteHostProgressReport writes the current per-host crawl progress to the given file.
+func WriteHostProgressReport(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, entry := range HostProgressGet() {
+		if err := encoder.Encode(entry); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// ReadHostProgressReport reads a report written by the host progress reporter.
+func ReadHostProgressReport(path string) ([]HostProgressEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []HostProgressEntry
+
+	decoder := json.NewDecoder(file)
+	for decoder.More() {
+		var entry HostProgressEntry
+		if err := decoder.Decode(&entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHostProgressReport(t *testing.T) {
+	globalStats = &stats{HostProgress: newHostProgress()}
+
+	HostProgressQueuedIncr("example.com")
+	HostProgressQueuedIncr("example.com")
+	HostProgressFetchedIncr("example.com", 1024)
+	HostProgressFailedIncr("example.org")
+
+	path := filepath.Join(t.TempDir(), "hosts.jsonl")
+	if err := WriteHostProgressReport(path); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadHostProgressReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	com := entries[0]
+	if com.Host != "example.com" || com.Queued != 2 || com.Fetched != 1 || com.BytesArchived != 1024 || com.Failed != 0 {
+		t.Errorf("unexpected entry for example.com: %+v", com)
+	}
+
+	if com.LastActivityTime.IsZero() {
+		t.Error("expected last activity time to be set")
+	}
+
+	org := entries[1]
+	if org.Host != "example.org" || org.Failed != 1 || org.Fetched != 0 {
+		t.Errorf("unexpected entry for example.org: %+v", org)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	globalStats = &stats{
+		URLsCrawled:      &rate{},
+		BytesArchived:    &counter{},
+		BytesDownloaded:  &counter{},
+		ArchiverRoutines: &counter{},
+		HostProgress:     newHostProgress(),
+	}
+
+	globalStats.URLsCrawled.incr(3)
+	HostProgressFetchedIncr("example.com", 100)
+	HostProgressFetchedIncr("example.org", 50)
+	HostProgressFailedIncr("example.org")
+	BytesArchivedAdd(150)
+	BytesDownloadedAdd(150)
+	BytesDownloadedAdd(20)
+
+	snapshot := Snapshot()
+	if snapshot.URLsFetched != 3 || snapshot.URLsFailed != 1 || snapshot.BytesArchived != 150 ||
+		snapshot.BytesDownloaded != 170 || snapshot.UniqueHosts != 2 {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+}
@@ -0,0 +1,108 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// hostRPSHalfLife is the time after which a fetch counts for half as much in the achieved RPS of its host
+const hostRPSHalfLife = 30 * time.Second
+
+// HostRPSEntry is the achieved number of requests per second of a single host.
+type HostRPSEntry struct {
+	Host string  `json:"host"`
+	RPS  float64 `json:"rps"`
+}
+
+// ewmaRate is an exponentially decaying event rate, in events per second.
+type ewmaRate struct {
+	value float64
+	last  time.Time
+}
+
+// hostRPS keeps an exponentially weighted moving average of the fetches per second of each host.
+type hostRPS struct {
+	sync.Mutex
+	decay   float64 // per second, ln(2) / half-life
+	data    map[string]*ewmaRate
+	nowFunc func() time.Time
+}
+
+func newHostRPS(halfLife time.Duration) *hostRPS {
+	return &hostRPS{
+		decay:   math.Ln2 / halfLife.Seconds(),
+		data:    make(map[string]*ewmaRate),
+		nowFunc: time.Now,
+	}
+}
+
+// valueAt returns the rate decayed up to now.
+func (h *hostRPS) valueAt(rate *ewmaRate, now time.Time) float64 {
+	return rate.value * math.Exp(-h.decay*now.Sub(rate.last).Seconds())
+}
+
+// observe records a fetch for the host.
+func (h *hostRPS) observe(host string) {
+	h.Lock()
+	defer h.Unlock()
+
+	now := h.nowFunc()
+
+	rate, ok := h.data[host]
+	if !ok {
+		rate = &ewmaRate{last: now}
+		h.data[host] = rate
+	}
+
+	// Each event adds the decay constant, so that a steady rate of r events per second converges to r
+	rate.value = h.valueAt(rate, now) + h.decay
+	rate.last = now
+}
+
+// get returns the achieved RPS of the host, 0 if it was never fetched.
+func (h *hostRPS) get(host string) float64 {
+	h.Lock()
+	defer h.Unlock()
+
+	rate, ok := h.data[host]
+	if !ok {
+		return 0
+	}
+
+	return h.valueAt(rate, h.nowFunc())
+}
+
+// top returns the n hosts with the highest achieved RPS, in decreasing order.
+func (h *hostRPS) top(n int) []HostRPSEntry {
+	h.Lock()
+	defer h.Unlock()
+
+	now := h.nowFunc()
+
+	entries := make([]HostRPSEntry, 0, len(h.data))
+	for host, rate := range h.data {
+		entries = append(entries, HostRPSEntry{Host: host, RPS: h.valueAt(rate, now)})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].RPS == entries[j].RPS {
+			return entries[i].Host < entries[j].Host
+		}
+		return entries[i].RPS > entries[j].RPS
+	})
+
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+
+	return entries
+}
+
+func (h *hostRPS) reset() {
+	h.Lock()
+	defer h.Unlock()
+
+	h.data = make(map[string]*ewmaRate)
+}
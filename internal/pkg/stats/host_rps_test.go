@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHostRPS(t *testing.T) {
+	now := time.Now()
+	h := newHostRPS(hostRPSHalfLife)
+	h.nowFunc = func() time.Time { return now }
+
+	// 10 fetches per second for 5 minutes on example.com, 1 per second on example.org
+	for i := 0; i < 3000; i++ {
+		now = now.Add(100 * time.Millisecond)
+		h.observe("example.com")
+		if i%10 == 0 {
+			h.observe("example.org")
+		}
+	}
+
+	if rps := h.get("example.com"); math.Abs(rps-10) > 0.5 {
+		t.Errorf("expected example.com to be around 10 RPS, got %f", rps)
+	}
+
+	if rps := h.get("example.org"); math.Abs(rps-1) > 0.1 {
+		t.Errorf("expected example.org to be around 1 RPS, got %f", rps)
+	}
+
+	if rps := h.get("example.net"); rps != 0 {
+		t.Errorf("expected 0 RPS for an unknown host, got %f", rps)
+	}
+
+	top := h.top(1)
+	if len(top) != 1 || top[0].Host != "example.com" {
+		t.Errorf("expected example.com to be the top host, got %v", top)
+	}
+
+	// The rate halves after the half-life without fetches
+	before := h.get("example.com")
+	now = now.Add(hostRPSHalfLife)
+	if after := h.get("example.com"); math.Abs(after-before/2) > 0.01 {
+		t.Errorf("expected the rate to halve after %s, got %f then %f", hostRPSHalfLife, before, after)
+	}
+
+	h.reset()
+	if top := h.top(10); len(top) != 0 {
+		t.Errorf("expected no hosts after reset, got %v", top)
+	}
+}
@@ -43,6 +43,56 @@ func SeedsFinishedGet() uint64 { return globalStats.SeedsFinished.get() }
 // SeedsFinishedReset resets the SeedsFinished counter to 0.
 func SeedsFinishedReset() { globalStats.SeedsFinished.reset() }
 
+/////////////////////////
+//    URLsEnqueued     //
+/////////////////////////
+
+// URLsEnqueuedIncr increments the URLsEnqueued counter by 1.
+func URLsEnqueuedIncr() {
+	globalStats.URLsEnqueued.incr(1)
+	if globalPromStats != nil {
+		globalPromStats.urlEnqueued.WithLabelValues(config.Get().Job, hostname, version).Inc()
+	}
+}
+
+// URLsEnqueuedGet returns the current value of the URLsEnqueued counter.
+func URLsEnqueuedGet() uint64 { return globalStats.URLsEnqueued.get() }
+
+// URLsEnqueuedReset resets the URLsEnqueued counter to 0.
+func URLsEnqueuedReset() { globalStats.URLsEnqueued.reset() }
+
+//////////////////////////
+//     BytesArchived    //
+//////////////////////////
+
+// BytesArchivedAdd adds the given number of bytes to the BytesArchived counter.
+func BytesArchivedAdd(bytes uint64) {
+	globalStats.BytesArchived.incr(bytes)
+	if globalPromStats != nil {
+		globalPromStats.bytesArchived.WithLabelValues(config.Get().Job, hostname, version).Add(float64(bytes))
+	}
+}
+
+// BytesArchivedGet returns the current value of the BytesArchived counter.
+func BytesArchivedGet() uint64 { return globalStats.BytesArchived.get() }
+
+// BytesArchivedReset resets the BytesArchived counter to 0.
+func BytesArchivedReset() { globalStats.BytesArchived.reset() }
+
+//////////////////////////
+//    BytesDownloaded   //
+//////////////////////////
+
+// BytesDownloadedAdd adds the given number of bytes to the BytesDownloaded counter.
+// Unlike BytesArchived, it also counts the bodies of the responses that were discarded before a retry.
+func BytesDownloadedAdd(bytes uint64) { globalStats.BytesDownloaded.incr(bytes) }
+
+// BytesDownloadedGet returns the current value of the BytesDownloaded counter.
+func BytesDownloadedGet() uint64 { return globalStats.BytesDownloaded.get() }
+
+// BytesDownloadedReset resets the BytesDownloaded counter to 0.
+func BytesDownloadedReset() { globalStats.BytesDownloaded.reset() }
+
 //////////////////////////
 // PreprocessorRoutines //
 //////////////////////////
@@ -207,6 +257,79 @@ func HTTPReturnCodesReset(key string) { globalStats.HTTPReturnCodes.reset(key) }
 // HTTPReturnCodesResetAll resets all HTTPReturnCodes counters to 0.
 func HTTPReturnCodesResetAll() { globalStats.HTTPReturnCodes.resetAll() }
 
+//////////////////////////
+//     HostProgress     //
+//////////////////////////
+
+// HostProgressQueuedIncr increments the number of URLs queued for the given host by 1.
+func HostProgressQueuedIncr(host string) {
+	globalStats.HostProgress.update(host, func(entry *HostProgressEntry) { entry.Queued++ })
+}
+
+// HostProgressFetchedIncr increments the number of URLs fetched for the given host by 1,
+// and adds the given number of bytes to the bytes archived for the host.
+func HostProgressFetchedIncr(host string, bytes uint64) {
+	globalStats.HostProgress.update(host, func(entry *HostProgressEntry) {
+		entry.Fetched++
+		entry.BytesArchived += bytes
+	})
+}
+
+// HostProgressFailedIncr increments the number of URLs that failed for the given host by 1.
+func HostProgressFailedIncr(host string) {
+	globalStats.HostProgress.update(host, func(entry *HostProgressEntry) { entry.Failed++ })
+}
+
+// HostProgressGet returns a snapshot of the crawl progress of all hosts, sorted by host.
+func HostProgressGet() []HostProgressEntry { return globalStats.HostProgress.snapshot() }
+
+// HostProgressReset resets the crawl progress of all hosts.
+func HostProgressReset() { globalStats.HostProgress.reset() }
+
+//////////////////////////
+//        HostRPS       //
+//////////////////////////
+
+// HostRPSIncr records a successful fetch in the achieved RPS of the given host.
+func HostRPSIncr(host string) { globalStats.HostRPS.observe(host) }
+
+// HostRPSGet returns the achieved requests per second of the given host,
+// as an exponential moving average with a 30 seconds half-life.
+func HostRPSGet(host string) float64 { return globalStats.HostRPS.get(host) }
+
+// HostRPSTop returns the n hosts with the highest achieved RPS, in decreasing order.
+func HostRPSTop(n int) []HostRPSEntry { return globalStats.HostRPS.top(n) }
+
+// HostRPSReset forgets the achieved RPS of all hosts.
+func HostRPSReset() { globalStats.HostRPS.reset() }
+
+//////////////////////////
+//      DomainGraph     //
+//////////////////////////
+
+// DomainGraphAddEdge records a link from the source host to the target host in the domain graph.
+func DomainGraphAddEdge(source, target string) { globalStats.DomainGraph.AddEdge(source, target) }
+
+// DomainGraphTop returns the n most linked host-to-host relationships, heaviest first.
+func DomainGraphTop(n int) []DomainEdge { return globalStats.DomainGraph.Top(n) }
+
+// DomainGraphReset removes all the edges of the domain graph.
+func DomainGraphReset() { globalStats.DomainGraph.Reset() }
+
+//////////////////////////
+//      FetchErrors     //
+//////////////////////////
+
+// FetchErrorsAdd records the outcome of a fetch in the error rate sliding window.
+func FetchErrorsAdd(failed bool) { globalStats.FetchErrors.add(failed) }
+
+// FetchErrorsRateGet returns the fraction of failed fetches in the sliding window,
+// and the number of fetches it currently holds.
+func FetchErrorsRateGet() (float64, int) { return globalStats.FetchErrors.rate() }
+
+// FetchErrorsReset empties the error rate sliding window.
+func FetchErrorsReset() { globalStats.FetchErrors.reset() }
+
 //////////////////////////
 // WarcWritingQueueSize //
 //////////////////////////
@@ -12,6 +12,8 @@ import (
 type prometheusStats struct {
 	urlCrawled             *prometheus.CounterVec
 	finishedSeeds          *prometheus.CounterVec
+	urlEnqueued            *prometheus.CounterVec
+	bytesArchived          *prometheus.CounterVec
 	preprocessorRoutines   *prometheus.GaugeVec
 	archiverRoutines       *prometheus.GaugeVec
 	postprocessorRoutines  *prometheus.GaugeVec
@@ -37,6 +39,14 @@ func newPrometheusStats() *prometheusStats {
 			prometheus.CounterOpts{Name: config.Get().PrometheusPrefix + "finished_seeds", Help: "Total number of finished seeds"},
 			[]string{"project", "hostname", "version"},
 		),
+		urlEnqueued: prometheus.NewCounterVec(
+			prometheus.CounterOpts{Name: config.Get().PrometheusPrefix + "url_enqueued", Help: "Total number of URLs enqueued"},
+			[]string{"project", "hostname", "version"},
+		),
+		bytesArchived: prometheus.NewCounterVec(
+			prometheus.CounterOpts{Name: config.Get().PrometheusPrefix + "bytes_archived", Help: "Total number of response body bytes archived"},
+			[]string{"project", "hostname", "version"},
+		),
 		preprocessorRoutines: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{Name: config.Get().PrometheusPrefix + "preprocessor_routines", Help: "Number of preprocessor routines"},
 			[]string{"project", "hostname", "version"},
@@ -95,6 +105,8 @@ func newPrometheusStats() *prometheusStats {
 func registerPrometheusMetrics() {
 	prometheus.MustRegister(globalPromStats.urlCrawled)
 	prometheus.MustRegister(globalPromStats.finishedSeeds)
+	prometheus.MustRegister(globalPromStats.urlEnqueued)
+	prometheus.MustRegister(globalPromStats.bytesArchived)
 	prometheus.MustRegister(globalPromStats.preprocessorRoutines)
 	prometheus.MustRegister(globalPromStats.archiverRoutines)
 	prometheus.MustRegister(globalPromStats.postprocessorRoutines)
@@ -0,0 +1,42 @@
+package stats
+
+import "time"
+
+// CrawlStats is a snapshot of the crawl counters, collected in a single call.
+type CrawlStats struct {
+	URLsFetched      uint64        `json:"urls_fetched"`
+	URLsFailed       uint64        `json:"urls_failed"`
+	BytesDownloaded  uint64        `json:"bytes_downloaded"`
+	BytesArchived    uint64        `json:"bytes_archived"`
+	ActiveWorkers    uint64        `json:"active_workers"`
+	QueuedURLs       uint64        `json:"queued_urls"`
+	UniqueHosts      uint64        `json:"unique_hosts"`
+	ElapsedTime      time.Duration `json:"elapsed_time"`
+	FetchesPerSecond uint64        `json:"fetches_per_second"`
+}
+
+// Snapshot returns the current crawl stats. The counters are read one after the other,
+// so a snapshot taken during the crawl may be slightly inconsistent. Failures and unique
+// hosts come from the per-host progress. QueuedURLs is left to 0: the queue size is only
+// known by the source, the caller has to fill it.
+func Snapshot() CrawlStats {
+	snapshot := CrawlStats{
+		URLsFetched:      globalStats.URLsCrawled.getTotal(),
+		BytesDownloaded:  globalStats.BytesDownloaded.get(),
+		BytesArchived:    globalStats.BytesArchived.get(),
+		ActiveWorkers:    globalStats.ArchiverRoutines.get(),
+		FetchesPerSecond: globalStats.URLsCrawled.get(),
+	}
+
+	if !globalStats.StartTime.IsZero() {
+		snapshot.ElapsedTime = time.Since(globalStats.StartTime)
+	}
+
+	hosts := globalStats.HostProgress.snapshot()
+	snapshot.UniqueHosts = uint64(len(hosts))
+	for _, host := range hosts {
+		snapshot.URLsFailed += host.Failed
+	}
+
+	return snapshot
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/internetarchive/Zeno/internal/pkg/config"
 	"github.com/internetarchive/Zeno/internal/pkg/utils"
@@ -13,6 +14,9 @@ import (
 type stats struct {
 	URLsCrawled            *rate
 	SeedsFinished          *rate
+	URLsEnqueued           *counter
+	BytesArchived          *counter
+	BytesDownloaded        *counter
 	PreprocessorRoutines   *counter
 	ArchiverRoutines       *counter
 	PostprocessorRoutines  *counter
@@ -23,6 +27,11 @@ type stats struct {
 	MeanProcessBodyTime    *mean // in ms
 	MeanWaitOnFeedbackTime *mean // in ms
 	WARCWritingQueueSize   atomic.Int64
+	HostProgress           *hostProgress
+	FetchErrors            *errorWindow
+	HostRPS                *hostRPS
+	DomainGraph            *DomainGraphBuilder
+	StartTime              time.Time
 }
 
 var (
@@ -41,6 +50,9 @@ func Init() error {
 		globalStats = &stats{
 			URLsCrawled:            &rate{},
 			SeedsFinished:          &rate{},
+			URLsEnqueued:           &counter{},
+			BytesArchived:          &counter{},
+			BytesDownloaded:        &counter{},
 			PreprocessorRoutines:   &counter{},
 			ArchiverRoutines:       &counter{},
 			PostprocessorRoutines:  &counter{},
@@ -49,6 +61,11 @@ func Init() error {
 			MeanHTTPResponseTime:   &mean{},
 			MeanProcessBodyTime:    &mean{},
 			MeanWaitOnFeedbackTime: &mean{},
+			HostProgress:           newHostProgress(),
+			FetchErrors:            newErrorWindow(errorWindowSize()),
+			HostRPS:                newHostRPS(hostRPSHalfLife),
+			DomainGraph:            NewDomainGraphBuilder(),
+			StartTime:              time.Now(),
 		}
 
 		if config.Get() != nil && config.Get().Prometheus {
@@ -84,6 +101,9 @@ func Init() error {
 func Reset() {
 	globalStats.URLsCrawled.reset()
 	globalStats.SeedsFinished.reset()
+	globalStats.URLsEnqueued.reset()
+	globalStats.BytesArchived.reset()
+	globalStats.BytesDownloaded.reset()
 	globalStats.PreprocessorRoutines.reset()
 	globalStats.ArchiverRoutines.reset()
 	globalStats.PostprocessorRoutines.reset()
@@ -92,6 +112,19 @@ func Reset() {
 	globalStats.MeanHTTPResponseTime.reset()
 	globalStats.MeanProcessBodyTime.reset()
 	globalStats.MeanWaitOnFeedbackTime.reset()
+	globalStats.HostProgress.reset()
+	globalStats.FetchErrors.reset()
+	globalStats.HostRPS.reset()
+	globalStats.DomainGraph.Reset()
+}
+
+// errorWindowSize returns the number of fetches used to compute the error rate.
+func errorWindowSize() int {
+	if config.Get() != nil && config.Get().ErrorRateWindow > 0 {
+		return config.Get().ErrorRateWindow
+	}
+
+	return 100
 }
 
 // GetMapTUI returns a map of the current stats.
@@ -101,6 +134,7 @@ func GetMapTUI() map[string]interface{} {
 		"URL/s":                   globalStats.URLsCrawled.get(),
 		"Total URL crawled":       globalStats.URLsCrawled.getTotal(),
 		"Finished seeds":          globalStats.SeedsFinished.getTotal(),
+		"Enqueued URLs":           globalStats.URLsEnqueued.get(),
 		"Preprocessor routines":   globalStats.PreprocessorRoutines.get(),
 		"Archiver routines":       globalStats.ArchiverRoutines.get(),
 		"Postprocessor routines":  globalStats.PostprocessorRoutines.get(),
@@ -144,11 +144,24 @@ func (ui *UI) Start() error {
 	go ui.updateStatsLoop()
 	go ui.readLogsLoop()
 	go ui.pauseMonitor()
+	go ui.exitOnPipelineStop(controler.Stopped())
 
 	// Run tview (blocking).
 	return ui.app.Run()
 }
 
+// exitOnPipelineStop stops the TUI once the pipeline is stopped by something else
+// than the TUI, e.g. once --max-total-urls is reached.
+func (ui *UI) exitOnPipelineStop(stopped <-chan struct{}) {
+	select {
+	case <-ui.ctx.Done():
+	case <-stopped:
+		ui.cancel()
+		ui.wg.Wait()
+		ui.app.Stop()
+	}
+}
+
 // stop is called from Ctrl+C or Stop button: stops pipeline + TUI.
 func (ui *UI) stop() {
 	// Show a "Stopping..." modal
@@ -157,7 +170,7 @@ func (ui *UI) stop() {
 	ui.app.Draw()
 
 	// Stop the pipeline
-	controler.Stop()
+	controler.StopWithReason("stopped from the TUI")
 
 	// Cancel all UI loops
 	ui.cancel()
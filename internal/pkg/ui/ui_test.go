@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TestExitOnPipelineStop checks that the TUI exits when the pipeline is stopped
+// by something else than the TUI, e.g. once --max-total-urls is reached.
+func TestExitOnPipelineStop(t *testing.T) {
+	ui := New()
+
+	ui.app.SetScreen(tcell.NewSimulationScreen("UTF-8"))
+
+	// Wait for the TUI to be running before stopping the pipeline
+	drawn := make(chan struct{})
+	var drawnOnce sync.Once
+	ui.app.SetAfterDrawFunc(func(tcell.Screen) { drawnOnce.Do(func() { close(drawn) }) })
+
+	stopped := make(chan struct{})
+	go ui.exitOnPipelineStop(stopped)
+
+	done := make(chan error, 1)
+	go func() { done <- ui.app.Run() }()
+
+	select {
+	case <-drawn:
+	case <-time.After(5 * time.Second):
+		t.Fatal("TUI never drawn")
+	}
+
+	close(stopped)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error from the TUI: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("TUI still running after the pipeline stopped")
+	}
+
+	if ui.ctx.Err() == nil {
+		t.Error("expected the TUI loops to be cancelled")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/CorentinB/warc/pkg/spooledtempfile"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gabriel-vasile/mimetype"
+	"golang.org/x/net/html/charset"
 	"golang.org/x/net/idna"
 )
 
@@ -26,6 +27,10 @@ type URL struct {
 	Hops      int // This determines the number of hops this item is the result of, a hop is a "jump" from 1 page to another page
 	Redirects int
 
+	AnchorText      string  // Text of the link this URL was extracted from, if any
+	Priority        float64 // Rank of the URL among the queued URLs with the same hops, the highest is crawled first
+	PaginationDepth int     // Position of the URL in a chain of rel="next" pages, 0 if it isn't a next page
+
 	stringCache string
 	once        sync.Once
 }
@@ -45,7 +50,21 @@ func (u *URL) SetBody(body spooledtempfile.ReadSeekCloser) {
 
 func (u *URL) GetDocument() (doc *goquery.Document, err error) {
 	if u.document == nil {
-		u.document, err = goquery.NewDocumentFromReader(u.GetBody())
+		var contentType string
+		if u.response != nil {
+			contentType = u.response.Header.Get("Content-Type")
+		}
+
+		// Transcode the body to UTF-8 using the charset declared in the Content-Type
+		// header or in the <meta> tags, so that non-UTF-8 pages are parsed correctly
+		var body io.Reader
+		body, err = charset.NewReader(u.GetBody(), contentType)
+		if err != nil {
+			u.RewindBody()
+			body = u.GetBody()
+		}
+
+		u.document, err = goquery.NewDocumentFromReader(body)
 		if err != nil {
 			return nil, err
 		}